@@ -0,0 +1,92 @@
+// Package ai implements computer players for Battleship
+package ai
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
+)
+
+// Strategy decides how computer places its fleet and where it shoots
+type Strategy interface {
+	// PlaceFleet places computer's fleet on the board
+	PlaceFleet(board *rules.Board) error
+
+	// NextShot returns position that was not shot yet
+	NextShot() data.Point[int]
+
+	// NextShots returns up to n different positions that were not shot yet, it's used in salvo games
+	NextShots(n int) []data.Point[int]
+
+	// Observe is called with the result of each shot returned by NextShot
+	Observe(pos data.Point[int], result rules.ShotResult)
+}
+
+type Difficulty int
+
+const (
+	_ Difficulty = iota
+	DifficultyRandom
+	DifficultyHuntTarget
+	DifficultyProbability
+)
+
+// DefaultDifficulty is a difficulty used if not selected
+const DefaultDifficulty = DifficultyHuntTarget
+
+// Difficulties lists all difficulties from the easiest
+var Difficulties = []Difficulty{
+	DifficultyRandom,
+	DifficultyHuntTarget,
+	DifficultyProbability,
+}
+
+func (d Difficulty) String() string {
+	switch d {
+	case DifficultyRandom:
+		return "random"
+	case DifficultyHuntTarget:
+		return "hunt"
+	case DifficultyProbability:
+		return "density"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(d))
+	}
+}
+
+// DifficultyNames returns names of all difficulties from the easiest
+func DifficultyNames() []string {
+	names := make([]string, 0, len(Difficulties))
+	for _, difficulty := range Difficulties {
+		names = append(names, difficulty.String())
+	}
+
+	return names
+}
+
+// ParseDifficulty returns difficulty by its name
+func ParseDifficulty(name string) (Difficulty, error) {
+	for _, difficulty := range Difficulties {
+		if difficulty.String() == name {
+			return difficulty, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown difficulty: %q", name)
+}
+
+// NewStrategy creates strategy of given difficulty that plays by rule set
+func NewStrategy(difficulty Difficulty, ruleSet rules.RuleSet, rng *rand.Rand) Strategy {
+	switch difficulty {
+	case DifficultyRandom:
+		return NewRandomStrategy(ruleSet, rng)
+	case DifficultyHuntTarget:
+		return NewHuntTargetStrategy(ruleSet, rng)
+	case DifficultyProbability:
+		return NewProbabilityStrategy(ruleSet, rng)
+	default:
+		panic(fmt.Sprintf("unknown difficulty: %d", difficulty))
+	}
+}
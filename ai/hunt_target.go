@@ -0,0 +1,111 @@
+package ai
+
+import (
+	"math/rand"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
+)
+
+// HuntTargetStrategy shoots at random cells in checkerboard pattern until it hits a ship, then it shoots around hits
+// until the ship is destroyed
+type HuntTargetStrategy struct {
+	tracker
+}
+
+func NewHuntTargetStrategy(ruleSet rules.RuleSet, rng *rand.Rand) *HuntTargetStrategy {
+	return &HuntTargetStrategy{
+		tracker: newTracker(ruleSet, rng),
+	}
+}
+
+func (s *HuntTargetStrategy) NextShot() data.Point[int] {
+	if targets := s.targets(); len(targets) > 0 {
+		return s.pick(targets)
+	}
+
+	cells := s.unknown()
+
+	// Ships longer than one cell always cover a cell of the same color as top left corner
+	if s.remaining[0] == 0 {
+		var checkerboard []data.Point[int]
+		for _, pos := range cells {
+			if (pos.X+pos.Y)%2 == 0 {
+				checkerboard = append(checkerboard, pos)
+			}
+		}
+
+		if len(checkerboard) > 0 {
+			cells = checkerboard
+		}
+	}
+
+	return s.pick(cells)
+}
+
+func (s *HuntTargetStrategy) NextShots(n int) []data.Point[int] {
+	return s.nextShots(n, s.NextShot)
+}
+
+// targets returns cells that may continue already hit ships, line of hits is continued first, but if ships may
+// touch, hits may belong to different ships, so then all cells next to hits are tried
+func (s *HuntTargetStrategy) targets() []data.Point[int] {
+	if len(s.hits) == 0 {
+		return nil
+	}
+
+	if len(s.hits) > 1 {
+		if targets := s.shootable(s.lineEnds()); len(targets) > 0 {
+			return targets
+		}
+	}
+
+	var candidates []data.Point[int]
+	for _, hit := range s.hits {
+		for _, dir := range directions {
+			candidates = append(candidates, hit.Add(dir))
+		}
+	}
+
+	return s.shootable(candidates)
+}
+
+// lineEnds returns cells right before and after hits if they all are in the same row or column
+func (s *HuntTargetStrategy) lineEnds() []data.Point[int] {
+	minPos, maxPos := s.hits[0], s.hits[0]
+	horizontal, vertical := true, true
+	for _, hit := range s.hits[1:] {
+		horizontal = horizontal && hit.Y == minPos.Y
+		vertical = vertical && hit.X == minPos.X
+
+		if hit.X < minPos.X || hit.Y < minPos.Y {
+			minPos = hit
+		}
+		if hit.X > maxPos.X || hit.Y > maxPos.Y {
+			maxPos = hit
+		}
+	}
+
+	var dir data.Point[int]
+	switch {
+	case horizontal:
+		dir = data.NewPoint(1, 0)
+	case vertical:
+		dir = data.NewPoint(0, 1)
+	default:
+		return nil
+	}
+
+	return []data.Point[int]{minPos.Sub(dir), maxPos.Add(dir)}
+}
+
+func (s *HuntTargetStrategy) shootable(candidates []data.Point[int]) []data.Point[int] {
+	var targets []data.Point[int]
+	for _, pos := range candidates {
+		if s.canShoot(pos) {
+			targets = append(targets, pos)
+		}
+	}
+
+	return targets
+}
@@ -0,0 +1,110 @@
+package ai
+
+import (
+	"math/rand"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
+)
+
+// hitPlacementWeight increases weight of ship placements that go through already hit cells
+const hitPlacementWeight = 8
+
+// ProbabilityStrategy shoots at the cell covered by the largest number of possible placements of remaining ships,
+// placements can't cover missed cells (including ones around destroyed ships) and destroyed ships
+type ProbabilityStrategy struct {
+	tracker
+}
+
+func NewProbabilityStrategy(ruleSet rules.RuleSet, rng *rand.Rand) *ProbabilityStrategy {
+	return &ProbabilityStrategy{
+		tracker: newTracker(ruleSet, rng),
+	}
+}
+
+func (s *ProbabilityStrategy) NextShot() data.Point[int] {
+	density := s.density()
+
+	var best []data.Point[int]
+	bestDensity := 0
+	for _, pos := range s.unknown() {
+		switch d := density[pos.Y][pos.X]; {
+		case d > bestDensity:
+			best = []data.Point[int]{pos}
+			bestDensity = d
+		case d == bestDensity:
+			best = append(best, pos)
+		}
+	}
+
+	return s.pick(best)
+}
+
+func (s *ProbabilityStrategy) NextShots(n int) []data.Point[int] {
+	return s.nextShots(n, s.NextShot)
+}
+
+// density returns number of possible ship placements for each cell, when there are hits of not destroyed ship, only
+// placements going through them are counted
+func (s *ProbabilityStrategy) density() [][]int {
+	density := make([][]int, s.shots.Height())
+	for y := range density {
+		density[y] = make([]int, s.shots.Width())
+	}
+
+	for i, count := range s.remaining {
+		length := i + 1
+		if count == 0 {
+			continue
+		}
+
+		for _, dir := range []data.Point[int]{{X: 1, Y: 0}, {X: 0, Y: 1}} {
+			// Single cell ship has only one orientation
+			if length == 1 && dir.Y == 1 {
+				continue
+			}
+
+			for y := 0; y < s.shots.Height(); y++ {
+				for x := 0; x < s.shots.Width(); x++ {
+					hits, ok := s.placement(data.NewPoint(x, y), dir, length)
+					if !ok || (len(s.hits) > 0 && hits == 0) {
+						continue
+					}
+
+					weight := count * (1 + hitPlacementWeight*hits)
+					for j, pos := 0, data.NewPoint(x, y); j < length; j, pos = j+1, pos.Add(dir) {
+						density[pos.Y][pos.X] += weight
+					}
+				}
+			}
+		}
+	}
+
+	return density
+}
+
+// placement reports whether ship can be placed at position and number of hit cells it covers, unless ships may touch
+// freely, ship can't end next to another hit, because then it would be longer
+func (s *ProbabilityStrategy) placement(pos, dir data.Point[int], length int) (int, bool) {
+	touchFree := s.shots.RuleSet().Touching == rules.TouchingFree
+	if !touchFree && s.isHit(pos.Sub(dir)) {
+		return 0, false
+	}
+
+	hits := 0
+	for j := 0; j < length; j, pos = j+1, pos.Add(dir) {
+		if !s.shots.InBounds(pos) || s.shots.At(pos) == rules.CellMiss || s.sunk[pos.Y][pos.X] {
+			return 0, false
+		}
+
+		if s.shots.At(pos) == rules.CellShipHit {
+			hits++
+		}
+	}
+
+	if !touchFree && s.isHit(pos) {
+		return 0, false
+	}
+
+	return hits, true
+}
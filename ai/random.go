@@ -0,0 +1,27 @@
+package ai
+
+import (
+	"math/rand"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
+)
+
+// RandomStrategy shoots at random cells
+type RandomStrategy struct {
+	tracker
+}
+
+func NewRandomStrategy(ruleSet rules.RuleSet, rng *rand.Rand) *RandomStrategy {
+	return &RandomStrategy{
+		tracker: newTracker(ruleSet, rng),
+	}
+}
+
+func (s *RandomStrategy) NextShot() data.Point[int] {
+	return s.pick(s.unknown())
+}
+
+func (s *RandomStrategy) NextShots(n int) []data.Point[int] {
+	return s.nextShots(n, s.NextShot)
+}
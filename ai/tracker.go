@@ -0,0 +1,159 @@
+package ai
+
+import (
+	"math/rand"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
+)
+
+// tracker keeps everything strategy knows about opponent's board
+type tracker struct {
+	rng *rand.Rand
+
+	// shots contains results of all shots, cells around destroyed ships are marked as missed
+	shots *rules.Board
+	// sunk marks cells of destroyed ships
+	sunk [][]bool
+	// hits contains hit cells of ships that are not destroyed yet
+	hits []data.Point[int]
+	// remaining is a number of not destroyed ships of each length
+	remaining rules.Fleet
+	// pending contains cells already picked for the salvo that is being prepared
+	pending map[data.Point[int]]bool
+}
+
+func newTracker(ruleSet rules.RuleSet, rng *rand.Rand) tracker {
+	remaining := make(rules.Fleet, len(ruleSet.Fleet))
+	copy(remaining, ruleSet.Fleet)
+
+	sunk := make([][]bool, ruleSet.Height)
+	for y := range sunk {
+		sunk[y] = make([]bool, ruleSet.Width)
+	}
+
+	return tracker{
+		rng:       rng,
+		shots:     rules.NewBoard(ruleSet),
+		sunk:      sunk,
+		remaining: remaining,
+	}
+}
+
+func (t *tracker) PlaceFleet(board *rules.Board) error {
+	return board.PlaceRandomFleet(t.rng)
+}
+
+func (t *tracker) Observe(pos data.Point[int], result rules.ShotResult) {
+	t.shots.Mark(pos, result)
+
+	switch result {
+	case rules.ShotHit:
+		t.hits = append(t.hits, pos)
+		t.markDiagonals(pos)
+	case rules.ShotDestroyed:
+		t.markDiagonals(pos)
+
+		ship := t.shipAt(pos)
+		for _, cell := range ship {
+			t.sunk[cell.Y][cell.X] = true
+		}
+
+		hits := t.hits[:0]
+		for _, hit := range t.hits {
+			if !t.sunk[hit.Y][hit.X] {
+				hits = append(hits, hit)
+			}
+		}
+		t.hits = hits
+
+		if len(ship) <= len(t.remaining) && t.remaining[len(ship)-1] > 0 {
+			t.remaining[len(ship)-1]--
+		}
+	}
+}
+
+// markDiagonals marks cells diagonal to hit as missed, if ships never touch there can't be any ship
+func (t *tracker) markDiagonals(pos data.Point[int]) {
+	if t.shots.RuleSet().Touching != rules.TouchingNone {
+		return
+	}
+
+	for _, diagonal := range []data.Point[int]{{X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: 1}, {X: -1, Y: -1}} {
+		if cell := pos.Add(diagonal); t.shots.InBounds(cell) && t.shots.At(cell) == rules.CellEmpty {
+			t.shots.Set(cell, rules.CellMiss)
+		}
+	}
+}
+
+// shipAt returns the longest straight line of hit cells going through position, if ships may touch by sides, it's
+// only a guess and line may include hits of other ships
+func (t *tracker) shipAt(pos data.Point[int]) []data.Point[int] {
+	var ship []data.Point[int]
+	for _, dir := range []data.Point[int]{{X: 1, Y: 0}, {X: 0, Y: 1}} {
+		line := []data.Point[int]{pos}
+		for next := pos.Add(dir); t.isHit(next); next = next.Add(dir) {
+			line = append(line, next)
+		}
+		for next := pos.Sub(dir); t.isHit(next); next = next.Sub(dir) {
+			line = append(line, next)
+		}
+
+		if len(line) > len(ship) {
+			ship = line
+		}
+	}
+
+	return ship
+}
+
+func (t *tracker) isHit(pos data.Point[int]) bool {
+	return t.shots.InBounds(pos) && t.shots.At(pos) == rules.CellShipHit
+}
+
+// nextShots picks up to n shots one by one with next, already picked cells are treated as shot
+func (t *tracker) nextShots(n int, next func() data.Point[int]) []data.Point[int] {
+	t.pending = make(map[data.Point[int]]bool, n)
+	defer func() {
+		t.pending = nil
+	}()
+
+	shots := make([]data.Point[int], 0, n)
+	for len(shots) < n && len(t.unknown()) > 0 {
+		pos := next()
+		t.pending[pos] = true
+		shots = append(shots, pos)
+	}
+
+	return shots
+}
+
+func (t *tracker) canShoot(pos data.Point[int]) bool {
+	return t.shots.CanShoot(pos) && !t.pending[pos]
+}
+
+// unknown returns all positions that were not shot yet
+func (t *tracker) unknown() []data.Point[int] {
+	var cells []data.Point[int]
+	for y := 0; y < t.shots.Height(); y++ {
+		for x := 0; x < t.shots.Width(); x++ {
+			pos := data.NewPoint(x, y)
+			if t.canShoot(pos) {
+				cells = append(cells, pos)
+			}
+		}
+	}
+
+	return cells
+}
+
+func (t *tracker) pick(cells []data.Point[int]) data.Point[int] {
+	return cells[t.rng.Intn(len(cells))]
+}
+
+var directions = []data.Point[int]{
+	{X: 1, Y: 0},
+	{X: -1, Y: 0},
+	{X: 0, Y: 1},
+	{X: 0, Y: -1},
+}
@@ -2,79 +2,230 @@ package main
 
 import (
 	"image/color"
+	"math"
 	"strconv"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"golang.org/x/image/font"
 
 	"github.com/mymmrac/battleship/core"
 	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
 	"github.com/mymmrac/battleship/ui"
 )
 
-const cellsCount = 10
-const cellSize float32 = 32
-const cellPaddingSize float32 = 4
-const innerCellSize = cellSize - cellPaddingSize
+const (
+	maxCellSize float32 = 32
+	minCellSize float32 = 8
 
-type CellKind int
+	// cellPaddingRatio is a size of gap between cells relative to cell size
+	cellPaddingRatio = 1.0 / 8
 
-const (
-	CellEmpty CellKind = iota
-	CellShip
-	CellMiss
-	CellShipHit
+	// wheelGestureGap is a pause between wheel events that ends scroll gesture, touchpads send many events per gesture
+	wheelGestureGap = 200 * time.Millisecond
 )
 
+// boardAreaSize is a max size of the board with labels, both boards of max size fit the window side by side
+const boardAreaSize = 11 * maxCellSize
+
 type Board struct {
 	core.BaseGameObject
 
-	pos      data.Point[float32]
-	cells    [cellsCount][cellsCount]CellKind
+	pos   data.Point[float32]
+	model *rules.Board
+	faces CellFaces
+
+	cellSize float32
 	fontFace font.Face
 
 	hover    bool
 	hoverPos data.Point[int]
+
+	// targets are cells selected for the salvo
+	targets []data.Point[int]
+
+	cursor data.Point[float32]
+	drag   *shipDrag
+	// lastWheel is a time of the last wheel event, dragged ship rotates only once per scroll gesture
+	lastWheel time.Time
+}
+
+// shipDrag is a ship that player drags from the shipyard or moves on the board
+type shipDrag struct {
+	length   int
+	vertical bool
+
+	// grab is an index of ship cell that cursor holds, ship rotates around it
+	grab int
+
+	// from is a ship that was picked up from the board, it's placed back if drop fails
+	from []data.Point[int]
 }
 
-func NewBoard(pos data.Point[float32], fontFace font.Face) *Board {
-	return &Board{
+func NewBoard(pos data.Point[float32], ruleSet rules.RuleSet, faces CellFaces) *Board {
+	board := &Board{
 		BaseGameObject: core.NewBaseGameObject(),
 		pos:            pos,
-		cells:          [cellsCount][cellsCount]CellKind{},
-		fontFace:       fontFace,
+		faces:          faces,
+	}
+	board.Reset(ruleSet)
+
+	return board
+}
+
+// Reset replaces board with an empty one that uses rule set, cells are scaled to fit board area
+func (b *Board) Reset(ruleSet rules.RuleSet) {
+	b.model = rules.NewBoard(ruleSet)
+	b.cellSize = fitCellSize(boardAreaSize, boardAreaSize, ruleSet.Width+1, ruleSet.Height+1)
+	b.fontFace = b.faces.For(b.cellSize)
+	b.hover = false
+	b.targets = nil
+	b.drag = nil
+}
+
+// ToggleTarget selects cell for the salvo or removes it from selection, no more than limit cells can be selected
+func (b *Board) ToggleTarget(pos data.Point[int], limit int) {
+	for i, target := range b.targets {
+		if target == pos {
+			b.targets = append(b.targets[:i], b.targets[i+1:]...)
+			return
+		}
+	}
+
+	if len(b.targets) < limit {
+		b.targets = append(b.targets, pos)
 	}
 }
 
+func (b *Board) Targets() []data.Point[int] {
+	return b.targets
+}
+
+func (b *Board) ClearTargets() {
+	b.targets = nil
+}
+
 func (b *Board) Update(cp data.Point[float32]) {
 	b.hoverPos.X, b.hoverPos.Y, b.hover = b.cellOn(cp)
+	b.cursor = cp
+
+	wheelStarted := false
+	if _, wheel := ebiten.Wheel(); wheel != 0 {
+		now := time.Now()
+		wheelStarted = now.Sub(b.lastWheel) > wheelGestureGap
+		b.lastWheel = now
+	}
+
+	if b.drag == nil {
+		return
+	}
+
+	if wheelStarted || inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		b.drag.vertical = !b.drag.vertical
+	}
+
+	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		b.drop()
+	}
 }
 
 func (b *Board) CursorPointer() bool {
-	return b.hover
+	return b.hover || b.drag != nil
 }
 
 func (b *Board) Disable() {
 	b.hover = false
+	b.cancelDrag()
 	b.BaseGameObject.Disable()
 }
 
+// StartDrag starts dragging a new ship held by cell at grab index
+func (b *Board) StartDrag(length, grab int) {
+	b.drag = &shipDrag{
+		length: length,
+		grab:   grab,
+	}
+}
+
+// PickUp starts moving ship at position, reports whether there was a ship
+func (b *Board) PickUp(pos data.Point[int]) bool {
+	ship := b.model.ShipAt(pos)
+	if ship == nil {
+		return false
+	}
+
+	b.model.RemoveShip(ship)
+
+	first := ship[0]
+	for _, cell := range ship {
+		if cell.X < first.X || cell.Y < first.Y {
+			first = cell
+		}
+	}
+
+	b.drag = &shipDrag{
+		length:   len(ship),
+		vertical: len(ship) > 1 && ship[0].X == ship[1].X,
+		grab:     pos.X - first.X + pos.Y - first.Y,
+		from:     ship,
+	}
+	return true
+}
+
+func (b *Board) Dragging() bool {
+	return b.drag != nil
+}
+
+// draggedShip returns cells of dragged ship if it was dropped at hovered cell
+func (b *Board) draggedShip() []data.Point[int] {
+	start := b.hoverPos
+	if b.drag.vertical {
+		start.Y -= b.drag.grab
+	} else {
+		start.X -= b.drag.grab
+	}
+
+	return rules.ShipCells(start, b.drag.length, b.drag.vertical)
+}
+
+func (b *Board) drop() {
+	if b.hover && b.model.PlaceShip(b.draggedShip()) {
+		b.drag = nil
+		return
+	}
+
+	b.cancelDrag()
+}
+
+// cancelDrag stops dragging and returns moved ship to where it was
+func (b *Board) cancelDrag() {
+	if b.drag != nil && b.drag.from != nil {
+		b.model.PlaceShip(b.drag.from)
+	}
+	b.drag = nil
+}
+
 func (b *Board) Draw(screen *ebiten.Image) {
+	cellPadding := b.cellSize * cellPaddingRatio
+	innerCellSize := b.cellSize - cellPadding
+
 	// Border
 	vector.StrokeRect(
 		screen,
-		b.pos.X-cellPaddingSize,
-		b.pos.Y-cellPaddingSize,
-		(cellsCount+1)*cellSize+cellPaddingSize*2,
-		(cellsCount+1)*cellSize+cellPaddingSize*2,
+		b.pos.X-cellPadding,
+		b.pos.Y-cellPadding,
+		float32(b.model.Width()+1)*b.cellSize+cellPadding*2,
+		float32(b.model.Height()+1)*b.cellSize+cellPadding*2,
 		2,
 		ui.BorderColor,
 	)
 
 	// Outer cells
-	for y := 0; y < cellsCount+1; y++ {
-		for x := 0; x < cellsCount+1; x++ {
+	for y := 0; y < b.model.Height()+1; y++ {
+		for x := 0; x < b.model.Width()+1; x++ {
 			if x != 0 && y != 0 {
 				continue
 			}
@@ -92,15 +243,15 @@ func (b *Board) Draw(screen *ebiten.Image) {
 	}
 
 	// Outer cells text
-	for y := 0; y < cellsCount+1; y++ {
-		for x := 0; x < cellsCount+1; x++ {
+	for y := 0; y < b.model.Height()+1; y++ {
+		for x := 0; x < b.model.Width()+1; x++ {
 			if x != 0 && y != 0 {
 				continue
 			}
 
 			var cellText string
 			if x >= 1 && y == 0 {
-				cellText = string(rune('A' + x - 1))
+				cellText = rules.ColumnName(x - 1)
 			} else if x == 0 && y >= 1 {
 				cellText = strconv.Itoa(y)
 			}
@@ -110,27 +261,27 @@ func (b *Board) Draw(screen *ebiten.Image) {
 				screen,
 				b.fontFace,
 				cellText,
-				int(pos.X+cellSize/2),
-				int(pos.Y+cellSize/2),
+				int(pos.X+b.cellSize/2),
+				int(pos.Y+b.cellSize/2),
 				ui.TextDarkColor,
 			)
 		}
 	}
 
 	// Inner cells
-	for y := 0; y < cellsCount; y++ {
-		for x := 0; x < cellsCount; x++ {
-			cell := b.cells[y][x]
+	for y := 0; y < b.model.Height(); y++ {
+		for x := 0; x < b.model.Width(); x++ {
+			cell := b.model.At(data.NewPoint(x, y))
 
 			var clr color.Color
 			switch cell {
-			case CellEmpty:
+			case rules.CellEmpty:
 				clr = ui.EmptyColor
-			case CellShip:
+			case rules.CellShip:
 				clr = ui.ShipColor
-			case CellMiss:
+			case rules.CellMiss:
 				clr = ui.MissColor
-			case CellShipHit:
+			case rules.CellShipHit:
 				clr = ui.ShipHitColor
 			default:
 				panic("unreachable")
@@ -148,193 +299,111 @@ func (b *Board) Draw(screen *ebiten.Image) {
 		}
 	}
 
-	if b.hover {
-		b.highlightCell(screen, b.hoverPos)
+	// Salvo targets
+	for _, target := range b.targets {
+		pos := b.cellPos(target.X+1, target.Y+1)
+		vector.DrawFilledCircle(screen, pos.X+b.cellSize/2, pos.Y+b.cellSize/2, innerCellSize/4, ui.HighlightColor)
 	}
-}
 
-func (b *Board) innerCellPos(x, y int) data.Point[float32] {
-	return data.NewPoint(
-		b.pos.X+float32(x)*cellSize+cellPaddingSize/2,
-		b.pos.Y+float32(y)*cellSize+cellPaddingSize/2,
-	)
-}
-
-func (b *Board) cellPos(x, y int) data.Point[float32] {
-	return data.NewPoint(
-		b.pos.X+float32(x)*cellSize,
-		b.pos.Y+float32(y)*cellSize,
-	)
-}
-
-func (b *Board) cellOn(p data.Point[float32]) (int, int, bool) {
-	p = p.Sub(b.pos.Add(data.NewPoint(cellSize, cellSize)))
-
-	for y := 0; y < cellsCount; y++ {
-		for x := 0; x < cellsCount; x++ {
-			cp := data.NewPoint(float32(x)*cellSize, float32(y)*cellSize)
-			if cp.X <= p.X && p.X <= cp.X+cellSize &&
-				cp.Y <= p.Y && p.Y <= cp.Y+cellSize {
-				return x, y, true
-			}
-		}
+	if b.hover {
+		b.highlightCell(screen, b.hoverPos)
 	}
 
-	return -1, -1, false
-}
-
-func (b *Board) canShoot(pos data.Point[int]) bool {
-	return b.cells[pos.Y][pos.X] == CellEmpty
+	b.drawDrag(screen)
 }
 
-func (b *Board) placeShip(pos data.Point[int]) {
-	x, y := pos.X, pos.Y
-
-	if b.cells[y][x] != CellEmpty {
+// drawDrag draws dragged ship snapped to board cells, colored by whether it can be dropped there
+func (b *Board) drawDrag(screen *ebiten.Image) {
+	if b.drag == nil || !b.hover {
 		return
 	}
 
-	// Check diagonals
-	if x > 0 { // LEFT
-		if y > 0 { // UP
-			if b.cells[y-1][x-1] == CellShip {
-				return
-			}
-		}
+	innerCellSize := b.cellSize - b.cellSize*cellPaddingRatio
 
-		if y < cellsCount-1 { // DOWN
-			if b.cells[y+1][x-1] == CellShip {
-				return
-			}
-		}
+	ship := b.draggedShip()
+	clr := ui.InvalidColor
+	if b.model.CanPlaceShip(ship) {
+		clr = ui.ValidColor
 	}
-	if x < cellsCount-1 { // RIGHT
-		if y > 0 { // UP
-			if b.cells[y-1][x+1] == CellShip {
-				return
-			}
-		}
 
-		if y < cellsCount-1 { // DOWN
-			if b.cells[y+1][x+1] == CellShip {
-				return
-			}
+	for _, cell := range ship {
+		if !b.model.InBounds(cell) {
+			continue
 		}
-	}
 
-	// Check length
-	length := 1
-	for dx := x - 1; dx >= 0 && b.cells[y][dx] == CellShip; dx-- {
-		length++
-	}
-	for dx := x + 1; dx < cellsCount && b.cells[y][dx] == CellShip; dx++ {
-		length++
-	}
-	for dy := y - 1; dy >= 0 && b.cells[dy][x] == CellShip; dy-- {
-		length++
-	}
-	for dy := y + 1; dy < cellsCount && b.cells[dy][x] == CellShip; dy++ {
-		length++
+		pos := b.innerCellPos(cell.X+1, cell.Y+1)
+		vector.DrawFilledRect(screen, pos.X, pos.Y, innerCellSize, innerCellSize, clr)
 	}
-	if length > len(allowedShips) {
-		return
-	}
-
-	b.cells[y][x] = CellShip
 }
 
-func (b *Board) FillIfDestroyed(pos data.Point[int]) bool {
-	if b.AtPos(pos) != CellShipHit {
-		return false
-	}
-
-	x := pos.X
-	y := pos.Y
-
-	lx := x
-	for dx := x - 1; dx >= 0 && b.At(dx, y) == CellShipHit; dx-- {
-		lx--
-	}
-	if lx > 0 && b.At(lx-1, y) == CellShip {
-		return false
-	}
-
-	rx := x
-	for dx := x + 1; dx < cellsCount && b.At(dx, y) == CellShipHit; dx++ {
-		rx++
-	}
-	if rx < cellsCount-1 && b.At(rx+1, y) == CellShip {
-		return false
+// drawDragOutside draws dragged ship following cursor when it's outside the board
+func (b *Board) drawDragOutside(screen *ebiten.Image) {
+	if b.drag == nil || b.hover {
+		return
 	}
 
-	ty := y
-	for dy := y - 1; dy >= 0 && b.At(x, dy) == CellShipHit; dy-- {
-		ty--
-	}
-	if ty > 0 && b.At(x, ty-1) == CellShip {
-		return false
-	}
+	innerCellSize := b.cellSize - b.cellSize*cellPaddingRatio
 
-	by := y
-	for dy := y + 1; dy < cellsCount && b.At(x, dy) == CellShipHit; dy++ {
-		by++
-	}
-	if by < cellsCount-1 && b.At(x, by+1) == CellShip {
-		return false
-	}
-
-	for i := ty - 1; i <= by+1; i++ {
-		if i < 0 || i >= cellsCount {
-			continue
+	for i := 0; i < b.drag.length; i++ {
+		offset := float32(i-b.drag.grab) * b.cellSize
+		pos := b.cursor.Sub(data.NewPoint(innerCellSize/2, innerCellSize/2))
+		if b.drag.vertical {
+			pos.Y += offset
+		} else {
+			pos.X += offset
 		}
 
-		for j := lx - 1; j <= rx+1; j++ {
-			if j < 0 || j >= cellsCount {
-				continue
-			}
-
-			if b.cells[i][j] == CellEmpty {
-				b.cells[i][j] = CellMiss
-			}
-		}
+		vector.DrawFilledRect(screen, pos.X, pos.Y, innerCellSize, innerCellSize, ui.ShipColor)
 	}
-
-	return true
 }
 
-func (b *Board) removeShip(pos data.Point[int]) {
-	if b.cells[pos.Y][pos.X] != CellShip {
-		return
-	}
-
-	b.cells[pos.Y][pos.X] = CellEmpty
+func (b *Board) innerCellPos(x, y int) data.Point[float32] {
+	cellPadding := b.cellSize * cellPaddingRatio
+	return data.NewPoint(
+		b.pos.X+float32(x)*b.cellSize+cellPadding/2,
+		b.pos.Y+float32(y)*b.cellSize+cellPadding/2,
+	)
 }
 
-func (b *Board) At(x, y int) CellKind {
-	return b.cells[y][x]
+func (b *Board) cellPos(x, y int) data.Point[float32] {
+	return data.NewPoint(
+		b.pos.X+float32(x)*b.cellSize,
+		b.pos.Y+float32(y)*b.cellSize,
+	)
 }
 
-func (b *Board) AtPos(pos data.Point[int]) CellKind {
-	return b.cells[pos.Y][pos.X]
+func (b *Board) cellOn(p data.Point[float32]) (int, int, bool) {
+	p = p.Sub(b.pos.Add(data.NewPoint(b.cellSize, b.cellSize)))
+	if p.X < 0 || p.Y < 0 {
+		return -1, -1, false
+	}
+
+	x, y := int(p.X/b.cellSize), int(p.Y/b.cellSize)
+	if x >= b.model.Width() || y >= b.model.Height() {
+		return -1, -1, false
+	}
+
+	return x, y, true
 }
 
 func (b *Board) highlightCell(screen *ebiten.Image, boardPos data.Point[int]) {
 	pos := b.cellPos(boardPos.X+1, boardPos.Y+1)
-	vector.StrokeRect(screen, pos.X, pos.Y, cellSize, cellSize, 4, ui.HighlightColor)
+	vector.StrokeRect(screen, pos.X, pos.Y, b.cellSize, b.cellSize, b.cellSize*cellPaddingRatio, ui.HighlightColor)
 }
 
-func (b *Board) SetAt(pos data.Point[int], kind CellKind) {
-	b.cells[pos.Y][pos.X] = kind
-}
+// fitCellSize returns the largest whole cell size that fits grid of cells into area
+func fitCellSize(width, height float32, columns, rows int) float32 {
+	size := float32(math.Floor(float64(width) / float64(columns)))
+	if rowSize := float32(math.Floor(float64(height) / float64(rows))); rowSize < size {
+		size = rowSize
+	}
 
-func (b *Board) HasAlive() bool {
-	for y := 0; y < cellsCount; y++ {
-		for x := 0; x < cellsCount; x++ {
-			if b.At(x, y) == CellShip {
-				return true
-			}
-		}
+	if size > maxCellSize {
+		return maxCellSize
+	}
+	if size < minCellSize {
+		return minCellSize
 	}
 
-	return false
+	return size
 }
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+
+	"github.com/mymmrac/battleship/client"
+	"github.com/mymmrac/battleship/core"
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/ui"
+)
+
+const (
+	chatPadding float32 = 8
+
+	// maxChatLines is a number of lines kept in chat history
+	maxChatLines = 100
+)
+
+// ChatBox shows chat history, the latest messages are at the bottom and long messages are wrapped
+type ChatBox struct {
+	core.BaseGameObject
+
+	pos      data.Point[float32]
+	width    float32
+	height   float32
+	fontFace font.Face
+
+	lines     []chatLine
+	emptyText string
+}
+
+type chatLine struct {
+	text       string
+	byOpponent bool
+}
+
+func NewChatBox(pos data.Point[float32], width, height float32, fontFace font.Face) *ChatBox {
+	return &ChatBox{
+		BaseGameObject: core.NewBaseGameObject(),
+		pos:            pos,
+		width:          width,
+		height:         height,
+		fontFace:       fontFace,
+	}
+}
+
+func (c *ChatBox) SetEmptyText(text string) {
+	c.emptyText = text
+}
+
+// Add appends message of the author to history
+func (c *ChatBox) Add(author, text string, byOpponent bool) {
+	for _, line := range wrapText(c.fontFace, author+": "+text, c.width-chatPadding*2) {
+		c.lines = append(c.lines, chatLine{text: line, byOpponent: byOpponent})
+	}
+
+	if len(c.lines) > maxChatLines {
+		c.lines = c.lines[len(c.lines)-maxChatLines:]
+	}
+}
+
+func (c *ChatBox) Clear() {
+	c.lines = nil
+}
+
+func (c *ChatBox) Draw(screen *ebiten.Image) {
+	vector.StrokeRect(screen, c.pos.X, c.pos.Y, c.width, c.height, 2, ui.MutedColor)
+
+	if len(c.lines) == 0 {
+		ui.DrawCenteredText(screen, c.fontFace, c.emptyText,
+			int(c.pos.X+c.width/2), int(c.pos.Y+c.height/2), ui.MutedColor)
+		return
+	}
+
+	lineHeight := float32(c.fontFace.Metrics().Height.Ceil())
+	visible := int((c.height - chatPadding*2) / lineHeight)
+
+	lines := c.lines
+	if len(lines) > visible {
+		lines = lines[len(lines)-visible:]
+	}
+
+	for i, line := range lines {
+		var clr color.Color = ui.TextLightColor
+		if line.byOpponent {
+			clr = ui.HighlightColor
+		}
+
+		ui.DrawLeftCenteredText(screen, c.fontFace, line.text,
+			int(c.pos.X+chatPadding), int(c.pos.Y+chatPadding+lineHeight*(float32(i)+0.5)), clr)
+	}
+}
+
+// wrapText splits text into lines that fit width, words that don't fit on their own are split by characters
+func wrapText(fontFace font.Face, text string, width float32) []string {
+	fits := func(s string) bool {
+		return float32(font.MeasureString(fontFace, s).Ceil()) <= width
+	}
+
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+
+		if fits(candidate) {
+			line = candidate
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+
+		line = ""
+		for _, r := range word {
+			if line != "" && !fits(line+string(r)) {
+				lines = append(lines, line)
+				line = ""
+			}
+			line += string(r)
+		}
+	}
+
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// remoteOpponent returns client of the opponent connected through server, ok is false in game against computer
+func (g *Game) remoteOpponent() (*client.EventManagerClient, bool) {
+	remote, ok := g.opponent.(*client.EventManagerClient)
+	return remote, ok && remote != nil
+}
+
+func (g *Game) showChat() {
+	if _, ok := g.remoteOpponent(); !ok {
+		return
+	}
+
+	g.chatBox.Show()
+	g.chatInput.EnableAndShow()
+}
+
+func (g *Game) hideChat() {
+	g.chatBox.Hide()
+	g.chatInput.DisableAndHide()
+}
+
+// updateChat sends typed message when it's submitted
+func (g *Game) updateChat() {
+	if !g.chatInput.Submitted() {
+		return
+	}
+
+	text := strings.TrimSpace(g.chatInput.Text())
+	remote, ok := g.remoteOpponent()
+	if text == "" || !ok {
+		return
+	}
+
+	if err := remote.Chat(text); err != nil {
+		fmt.Println(err) // TODO: Fix me
+		return
+	}
+
+	g.chatInput.Clear()
+}
+
+// addChatMessage adds message relayed by server to history
+func (g *Game) addChatMessage(text string, byOpponent bool) {
+	author := "You"
+	if byOpponent {
+		author = g.opponentName
+	}
+
+	g.chatBox.Add(author, text, byOpponent)
+}
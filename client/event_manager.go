@@ -0,0 +1,339 @@
+// Package client implements battleship server client
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server/api"
+)
+
+const (
+	reconnectAttempts = 30
+	reconnectInterval = time.Second
+)
+
+type EventManagerClient struct {
+	playerID     uuid.UUID
+	eventManager api.EventManagerClient
+	sessionToken string
+
+	// lock guards stream replacement on reconnect and concurrent sends
+	lock   sync.Mutex
+	stream api.EventManager_EventsClient
+	closed bool
+}
+
+func NewEventManagerClient(eventManager api.EventManagerClient) (*EventManagerClient, error) {
+	stream, err := eventManager.Events(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventManagerClient{
+		playerID:     uuid.New(),
+		eventManager: eventManager,
+		stream:       stream,
+	}, nil
+}
+
+// Ping checks that server responds and measures round trip time of a single request
+func Ping(ctx context.Context, eventManager api.EventManagerClient) (time.Duration, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := eventManager.Events(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	err = stream.Send(&api.Event{
+		From:    events.UUIDToGRPC(uuid.New()),
+		Payload: &api.Event_ListGames{ListGames: &api.ListGames{}},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	event, err := stream.Recv()
+	if err != nil {
+		return 0, err
+	}
+
+	if event.GetListGames() == nil {
+		return 0, fmt.Errorf("unexpected response event: %T", event.GetPayload())
+	}
+
+	return time.Since(start), nil
+}
+
+func (c *EventManagerClient) send(event *api.Event) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	event.From = events.UUIDToGRPC(c.playerID)
+	return c.stream.Send(event)
+}
+
+func (c *EventManagerClient) recv() (*api.Event, error) {
+	c.lock.Lock()
+	stream := c.stream
+	c.lock.Unlock()
+
+	return stream.Recv()
+}
+
+// NewGame creates new game and returns its join code, code is empty for public games
+func (c *EventManagerClient) NewGame(hostName string, private bool, ruleSet rules.RuleSet) (string, error) {
+	err := c.send(&api.Event{Payload: &api.Event_NewGame{NewGame: &api.NewGame{
+		HostName: hostName,
+		Private:  private,
+		Rules:    events.RuleSetToGRPC(ruleSet),
+	}}})
+	if err != nil {
+		return "", err
+	}
+
+	event, err := c.recv()
+	if err != nil {
+		return "", err
+	}
+
+	switch payload := event.GetPayload().(type) {
+	case *api.Event_GameCreated:
+	// Created
+	case *api.Event_Error:
+		return "", errors.New(payload.Error.GetMessage())
+	default:
+		return "", fmt.Errorf("unexpected response event: %T", payload)
+	}
+
+	gameCreated := event.GetGameCreated()
+	c.sessionToken = gameCreated.GetSessionToken()
+
+	return gameCreated.GetCode(), nil
+}
+
+// ListGames returns public games waiting for opponent
+func (c *EventManagerClient) ListGames() ([]events.GameInfo, error) {
+	return c.listGames(false)
+}
+
+// ListRunningGames returns public games that are being played and can be watched
+func (c *EventManagerClient) ListRunningGames() ([]events.GameInfo, error) {
+	return c.listGames(true)
+}
+
+func (c *EventManagerClient) listGames(running bool) ([]events.GameInfo, error) {
+	err := c.send(&api.Event{Payload: &api.Event_ListGames{ListGames: &api.ListGames{Running: running}}})
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := c.recv()
+	if err != nil {
+		return nil, err
+	}
+
+	listGames := event.GetListGames()
+	if listGames == nil {
+		return nil, fmt.Errorf("unexpected response event: %T", event.GetPayload())
+	}
+
+	games := make([]events.GameInfo, 0, len(listGames.GetGames()))
+	for _, grpcInfo := range listGames.GetGames() {
+		var info events.GameInfo
+		info, err = events.GameInfoFromGRPC(grpcInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		games = append(games, info)
+	}
+
+	return games, nil
+}
+
+// JoinGame joins public game and returns its rule set
+func (c *EventManagerClient) JoinGame(gameID uuid.UUID) (rules.RuleSet, error) {
+	return c.join(&api.JoinGame{GameId: events.UUIDToGRPC(gameID)})
+}
+
+// JoinGameByCode joins private game and returns its rule set
+func (c *EventManagerClient) JoinGameByCode(code string) (rules.RuleSet, error) {
+	return c.join(&api.JoinGame{Code: code})
+}
+
+func (c *EventManagerClient) join(joinGame *api.JoinGame) (rules.RuleSet, error) {
+	err := c.send(&api.Event{Payload: &api.Event_JoinGame{JoinGame: joinGame}})
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	event, err := c.recv()
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	switch payload := event.GetPayload().(type) {
+	case *api.Event_JoinGame:
+		c.sessionToken = payload.JoinGame.GetSessionToken()
+		return events.RuleSetFromGRPC(payload.JoinGame.GetRules())
+	case *api.Event_Error:
+		return rules.RuleSet{}, errors.New(payload.Error.GetMessage())
+	default:
+		return rules.RuleSet{}, fmt.Errorf("unexpected response event: %T", payload)
+	}
+}
+
+// Spectate starts watching the game and returns event with its snapshot, after that game events can be received with
+// HandleGameEvents
+func (c *EventManagerClient) Spectate(gameID uuid.UUID, hideFleets bool) (*api.Event, error) {
+	err := c.send(&api.Event{Payload: &api.Event_Spectate{Spectate: &api.Spectate{
+		GameId:     events.UUIDToGRPC(gameID),
+		HideFleets: hideFleets,
+	}}})
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := c.recv()
+	if err != nil {
+		return nil, err
+	}
+
+	switch payload := event.GetPayload().(type) {
+	case *api.Event_SpectatorSnapshot:
+		return event, nil
+	case *api.Event_Error:
+		return nil, errors.New(payload.Error.GetMessage())
+	default:
+		return nil, fmt.Errorf("unexpected response event: %T", payload)
+	}
+}
+
+func (c *EventManagerClient) Ready(ships [][]data.Point[int]) error {
+	return c.send(&api.Event{Payload: &api.Event_Ready{
+		Ready: &api.Ready{Ready: true, Ships: events.ShipsToGRPC(ships)},
+	}})
+}
+
+func (c *EventManagerClient) NotReady() error {
+	return c.send(&api.Event{Payload: &api.Event_Ready{
+		Ready: &api.Ready{Ready: false},
+	}})
+}
+
+func (c *EventManagerClient) Shoot(pos data.Point[int]) error {
+	return c.send(&api.Event{Payload: &api.Event_Shoot{
+		Shoot: &api.Shoot{Pos: events.PointToGRPC(pos)},
+	}})
+}
+
+// Salvo fires all shots of the turn in salvo game
+func (c *EventManagerClient) Salvo(targets []data.Point[int]) error {
+	return c.send(&api.Event{Payload: &api.Event_Salvo{
+		Salvo: &api.Salvo{Targets: events.PointsToGRPC(targets)},
+	}})
+}
+
+// Chat sends message to the opponent, server relays it back to confirm that it was sent
+func (c *EventManagerClient) Chat(text string) error {
+	return c.send(&api.Event{Payload: &api.Event_Chat{
+		Chat: &api.Chat{Text: text},
+	}})
+}
+
+// HandleGameEvents sends received events to gameEvents, if connection is lost it tries to reconnect and resume the
+// game, on success snapshot of the game is sent as next event
+func (c *EventManagerClient) HandleGameEvents(gameEvents chan<- events.GameEvent) error {
+	for {
+		event, err := c.recv()
+		if err != nil {
+			if c.isClosed() {
+				return nil
+			}
+
+			if c.sessionToken == "" {
+				return err
+			}
+
+			gameEvents <- events.NewGameEventError(events.GameEventConnectionLost, err)
+			event, err = c.reconnect()
+			if err != nil {
+				return err
+			}
+		}
+
+		if event.GetPayload() == nil {
+			return errors.New("event without payload")
+		}
+
+		gameEvents <- events.NewServerEvent(event)
+	}
+}
+
+// reconnect opens new stream and asks server to resume the game, returns received snapshot event
+func (c *EventManagerClient) reconnect() (*api.Event, error) {
+	var err error
+	for attempt := 0; attempt < reconnectAttempts; attempt++ {
+		time.Sleep(reconnectInterval)
+
+		var stream api.EventManager_EventsClient
+		stream, err = c.eventManager.Events(context.Background())
+		if err != nil {
+			continue
+		}
+
+		c.lock.Lock()
+		c.stream = stream
+		c.lock.Unlock()
+
+		err = c.send(&api.Event{Payload: &api.Event_Resume{Resume: &api.Resume{SessionToken: c.sessionToken}}})
+		if err != nil {
+			continue
+		}
+
+		var event *api.Event
+		event, err = c.recv()
+		if err != nil {
+			continue
+		}
+
+		switch payload := event.GetPayload().(type) {
+		case *api.Event_Snapshot:
+			return event, nil
+		case *api.Event_Error:
+			return nil, fmt.Errorf("resume rejected: %s", payload.Error.GetMessage())
+		default:
+			return nil, fmt.Errorf("unexpected response event: %T", payload)
+		}
+	}
+
+	return nil, fmt.Errorf("reconnect failed: %w", err)
+}
+
+// Close ends the stream, after that HandleGameEvents returns without trying to reconnect
+func (c *EventManagerClient) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.closed = true
+	return c.stream.CloseSend()
+}
+
+func (c *EventManagerClient) isClosed() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.closed
+}
@@ -0,0 +1,426 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/mymmrac/battleship/ai"
+	"github.com/mymmrac/battleship/client"
+	bcmd "github.com/mymmrac/battleship/cmd"
+	"github.com/mymmrac/battleship/cmd/server"
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server/api"
+)
+
+const (
+	defaultBotName  = "Bot"
+	defaultBotDelay = 500 * time.Millisecond
+)
+
+func BattleshipBotFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("address", "a", "127.0.0.1", "Battleship server address used to connect")
+	cmd.Flags().StringP("port", "p", server.DefaultGRPCPort, "Battleship server port used to connect")
+	cmd.Flags().StringP("name", "n", defaultBotName, "Bot name shown to other players")
+	cmd.Flags().StringP("difficulty", "d", ai.DefaultDifficulty.String(),
+		"Bot difficulty: "+strings.Join(ai.DifficultyNames(), ", "))
+	cmd.Flags().StringP("code", "c", "", "Join private game by code instead of hosting new game")
+	cmd.Flags().BoolP("join", "j", false, "Join the oldest open game instead of hosting new game")
+	cmd.Flags().Bool("private", false, "Host private game")
+	cmd.Flags().BoolP("loop", "l", false, "Keep playing new games after game ends")
+	cmd.Flags().Duration("delay", defaultBotDelay, "Delay before each shot")
+	bcmd.RuleSetFlags(cmd)
+}
+
+type botConfig struct {
+	name       string
+	difficulty ai.Difficulty
+	ruleSet    rules.RuleSet
+	code       string
+	join       bool
+	private    bool
+	delay      time.Duration
+}
+
+func BattleshipBotRunE(cmd *cobra.Command, _ []string) error {
+	serverAddr, err := cmd.Flags().GetString("address")
+	if err != nil {
+		return err
+	}
+
+	serverPort, err := cmd.Flags().GetString("port")
+	if err != nil {
+		return err
+	}
+
+	loop, err := cmd.Flags().GetBool("loop")
+	if err != nil {
+		return err
+	}
+
+	var cfg botConfig
+	if cfg.name, err = cmd.Flags().GetString("name"); err != nil {
+		return err
+	}
+
+	difficultyName, err := cmd.Flags().GetString("difficulty")
+	if err != nil {
+		return err
+	}
+	if cfg.difficulty, err = ai.ParseDifficulty(difficultyName); err != nil {
+		return err
+	}
+
+	if cfg.code, err = cmd.Flags().GetString("code"); err != nil {
+		return err
+	}
+	if cfg.join, err = cmd.Flags().GetBool("join"); err != nil {
+		return err
+	}
+	if cfg.private, err = cmd.Flags().GetBool("private"); err != nil {
+		return err
+	}
+	if cfg.delay, err = cmd.Flags().GetDuration("delay"); err != nil {
+		return err
+	}
+	if cfg.ruleSet, err = bcmd.RuleSetFromFlags(cmd); err != nil {
+		return err
+	}
+
+	conn, err := grpc.Dial(serverAddr+":"+serverPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		if err = playGame(api.NewEventManagerClient(conn), cfg, rng); err != nil {
+			return err
+		}
+
+		if !loop {
+			return nil
+		}
+	}
+}
+
+// playGame creates or joins a game and plays it until the end
+func playGame(eventManager api.EventManagerClient, cfg botConfig, rng *rand.Rand) error {
+	c, err := client.NewEventManagerClient(eventManager)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+
+	b := &bot{
+		client:     c,
+		difficulty: cfg.difficulty,
+		rng:        rng,
+		delay:      cfg.delay,
+	}
+
+	ruleSet := cfg.ruleSet
+	switch {
+	case cfg.code != "":
+		if ruleSet, err = c.JoinGameByCode(cfg.code); err != nil {
+			return fmt.Errorf("join game: %w", err)
+		}
+		fmt.Println("Joined game by code", cfg.code)
+	case cfg.join:
+		var games []events.GameInfo
+		games, err = c.ListGames()
+		if err != nil {
+			return fmt.Errorf("list games: %w", err)
+		}
+		if len(games) == 0 {
+			return errors.New("no open games")
+		}
+
+		if ruleSet, err = c.JoinGame(games[0].ID); err != nil {
+			return fmt.Errorf("join game: %w", err)
+		}
+		fmt.Println("Joined game of", games[0].HostName)
+	default:
+		var code string
+		code, err = c.NewGame(cfg.name, cfg.private, ruleSet)
+		if err != nil {
+			return fmt.Errorf("new game: %w", err)
+		}
+
+		if code != "" {
+			fmt.Println("Waiting for opponent, join code:", code)
+		} else {
+			fmt.Println("Waiting for opponent...")
+		}
+		b.waiting = true
+	}
+
+	fmt.Println("Rules:", ruleSet)
+	b.ruleSet = ruleSet
+	b.strategy = ai.NewStrategy(b.difficulty, ruleSet, b.rng)
+
+	return b.play()
+}
+
+// bot plays single game using AI strategy
+type bot struct {
+	client     *client.EventManagerClient
+	ruleSet    rules.RuleSet
+	difficulty ai.Difficulty
+	rng        *rand.Rand
+	strategy   ai.Strategy
+	delay      time.Duration
+
+	waiting bool
+	myTurn  bool
+	hits    int
+	// shots is a number of shots in the current turn of salvo game
+	shots int
+}
+
+func (b *bot) play() error {
+	gameEvents := make(chan events.GameEvent)
+	handleErr := make(chan error, 1)
+	go func() {
+		handleErr <- b.client.HandleGameEvents(gameEvents)
+	}()
+
+	defer func() {
+		_ = b.client.Close()
+
+		// Drain events until client stops
+		for {
+			select {
+			case <-gameEvents:
+			case <-handleErr:
+				return
+			}
+		}
+	}()
+
+	if !b.waiting {
+		if err := b.sendReady(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case event := <-gameEvents:
+			done, err := b.handleEvent(event)
+			if err != nil || done {
+				return err
+			}
+		case err := <-handleErr:
+			handleErr <- err
+			if err == nil {
+				err = errors.New("connection closed")
+			}
+			return fmt.Errorf("connection lost: %w", err)
+		}
+
+		if b.myTurn {
+			if err := b.shoot(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleEvent updates bot's state, returns true if game ended
+func (b *bot) handleEvent(event events.GameEvent) (bool, error) {
+	switch event.EventType() {
+	case events.GameEventConnectionLost:
+		fmt.Println("Connection lost, reconnecting...")
+		return false, nil
+	case events.GameEventFromServer:
+	// Handled below
+	default:
+		return false, fmt.Errorf("unexpected event type: %d", event.EventType())
+	}
+
+	switch payload := event.(events.ServerEvent).Event.GetPayload().(type) {
+	case *api.Event_JoinGame:
+		fmt.Println("Opponent joined")
+		b.waiting = false
+		return false, b.sendReady()
+	case *api.Event_Ready:
+	// Game starts when both players are ready
+	case *api.Event_GameStarted:
+		b.myTurn = payload.GameStarted.GetMyTurn()
+		b.shots = int(payload.GameStarted.GetShots())
+	case *api.Event_ShotResult:
+		result, err := events.ShotResultFromGRPC(payload.ShotResult.GetOutcome())
+		if err != nil {
+			return false, err
+		}
+
+		if payload.ShotResult.GetByOpponent() {
+			b.myTurn = result == rules.ShotMiss
+			return false, nil
+		}
+
+		b.strategy.Observe(events.PointFromGRPC(payload.ShotResult.GetPos()), result)
+		if result != rules.ShotMiss {
+			b.hits++
+		}
+
+		// After the last ship is destroyed only game end is expected
+		b.myTurn = result != rules.ShotMiss && b.hits < b.ruleSet.Fleet.Cells()
+	case *api.Event_SalvoResult:
+		for _, shotResult := range payload.SalvoResult.GetResults() {
+			if shotResult.GetByOpponent() {
+				continue
+			}
+
+			result, err := events.ShotResultFromGRPC(shotResult.GetOutcome())
+			if err != nil {
+				return false, err
+			}
+
+			b.strategy.Observe(events.PointFromGRPC(shotResult.GetPos()), result)
+		}
+
+		b.shots = int(payload.SalvoResult.GetShots())
+		b.myTurn = b.shots > 0
+	case *api.Event_GameEnded:
+		if payload.GameEnded.GetWon() {
+			fmt.Println("Game ended: won")
+		} else {
+			fmt.Println("Game ended: lost")
+		}
+		return true, nil
+	case *api.Event_Chat:
+		if payload.Chat.GetByOpponent() {
+			fmt.Println("Opponent:", payload.Chat.GetText())
+		}
+	case *api.Event_OpponentLeft:
+		fmt.Println("Opponent left")
+		return true, nil
+	case *api.Event_Snapshot:
+		fmt.Println("Game resumed")
+		b.myTurn = payload.Snapshot.GetMyTurn()
+		b.shots = int(payload.Snapshot.GetShots())
+
+		// Results of shots may be lost with connection, so strategy learns them again from opponent's board
+		if payload.Snapshot.GetOpponentBoard() != nil {
+			if err := b.restoreStrategy(payload.Snapshot.GetOpponentBoard()); err != nil {
+				return false, fmt.Errorf("restore strategy: %w", err)
+			}
+		}
+
+		if payload.Snapshot.GetState() == api.GameState_GAME_STATE_PLACING && !payload.Snapshot.GetReady() {
+			return false, b.sendReady()
+		}
+	case *api.Event_Error:
+		return false, fmt.Errorf("server error: %s", payload.Error.GetMessage())
+	default:
+		return false, fmt.Errorf("unexpected server event: %T", payload)
+	}
+
+	return false, nil
+}
+
+// restoreStrategy replaces strategy with a new one that observed all shots at opponent's board, ships are destroyed
+// if there is no cell left to shoot next to their hit cells
+func (b *bot) restoreStrategy(grpcBoard *api.Board) error {
+	board := rules.NewBoard(b.ruleSet)
+	if err := events.BoardFromGRPC(grpcBoard, board); err != nil {
+		return err
+	}
+
+	b.strategy = ai.NewStrategy(b.difficulty, b.ruleSet, b.rng)
+	b.hits = 0
+
+	var hits []data.Point[int]
+	for y := 0; y < board.Height(); y++ {
+		for x := 0; x < board.Width(); x++ {
+			pos := data.NewPoint(x, y)
+			switch board.At(pos) {
+			case rules.CellMiss:
+				b.strategy.Observe(pos, rules.ShotMiss)
+			case rules.CellShipHit:
+				b.strategy.Observe(pos, rules.ShotHit)
+				hits = append(hits, pos)
+				b.hits++
+			}
+		}
+	}
+
+	sides := []data.Point[int]{{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1}}
+	visited := map[data.Point[int]]bool{}
+	for _, pos := range hits {
+		if visited[pos] {
+			continue
+		}
+		visited[pos] = true
+
+		// Hit cells connected by sides and whether any of them can still be continued by a ship cell
+		ship := []data.Point[int]{pos}
+		destroyed := true
+		for i := 0; i < len(ship); i++ {
+			for _, dir := range sides {
+				next := ship[i].Add(dir)
+				if !board.InBounds(next) || visited[next] {
+					continue
+				}
+
+				switch board.At(next) {
+				case rules.CellShipHit:
+					visited[next] = true
+					ship = append(ship, next)
+				case rules.CellEmpty:
+					destroyed = false
+				}
+			}
+		}
+
+		if destroyed {
+			b.strategy.Observe(pos, rules.ShotDestroyed)
+		}
+	}
+
+	return nil
+}
+
+func (b *bot) sendReady() error {
+	board := rules.NewBoard(b.ruleSet)
+	if err := b.strategy.PlaceFleet(board); err != nil {
+		return fmt.Errorf("place fleet: %w", err)
+	}
+
+	ships, err := board.FleetShips()
+	if err != nil {
+		return fmt.Errorf("fleet ships: %w", err)
+	}
+
+	if err = b.client.Ready(ships); err != nil {
+		return fmt.Errorf("ready: %w", err)
+	}
+
+	return nil
+}
+
+func (b *bot) shoot() error {
+	time.Sleep(b.delay)
+
+	if b.ruleSet.Salvo != rules.SalvoOff {
+		if err := b.client.Salvo(b.strategy.NextShots(b.shots)); err != nil {
+			return fmt.Errorf("salvo: %w", err)
+		}
+	} else if err := b.client.Shoot(b.strategy.NextShot()); err != nil {
+		return fmt.Errorf("shoot: %w", err)
+	}
+
+	// Wait for result before next shot
+	b.myTurn = false
+	return nil
+}
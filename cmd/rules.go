@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mymmrac/battleship/rules"
+)
+
+// RuleSetFlags adds flags used to choose rule set
+func RuleSetFlags(cmd *cobra.Command) {
+	names := append(rules.RuleSetNames(), rules.CustomRuleSetName)
+
+	cmd.Flags().StringP("rules", "r", rules.DefaultRuleSet.Name, "Rule set: "+strings.Join(names, ", "))
+	cmd.Flags().Int("board-size", rules.DefaultRuleSet.Width, "Board size of custom rule set")
+	cmd.Flags().String("fleet", rules.DefaultRuleSet.Fleet.String(),
+		"Ship lengths of custom rule set, for example: 5-4-3-3-2")
+
+	touchings := make([]string, 0, len(rules.Touchings))
+	for _, touching := range rules.Touchings {
+		touchings = append(touchings, touching.String())
+	}
+	cmd.Flags().String("touching", rules.DefaultRuleSet.Touching.String(),
+		"How ships of custom rule set may touch: "+strings.Join(touchings, ", "))
+	cmd.Flags().String("salvo", rules.SalvoOff.String(),
+		"Shots per turn of custom rule set: off, ships (one per alive ship) or a number of shots")
+}
+
+// RuleSetFlagsChanged reports whether any of flags added with RuleSetFlags was set
+func RuleSetFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range []string{"rules", "board-size", "fleet", "touching", "salvo"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RuleSetFromFlags returns rule set chosen by flags added with RuleSetFlags
+func RuleSetFromFlags(cmd *cobra.Command) (rules.RuleSet, error) {
+	name, err := cmd.Flags().GetString("rules")
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	if !strings.EqualFold(name, rules.CustomRuleSetName) {
+		for _, ruleSet := range rules.RuleSets {
+			if strings.EqualFold(ruleSet.Name, name) {
+				return ruleSet, nil
+			}
+		}
+
+		return rules.RuleSet{}, fmt.Errorf("unknown rule set: %q", name)
+	}
+
+	boardSize, err := cmd.Flags().GetInt("board-size")
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	fleetText, err := cmd.Flags().GetString("fleet")
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	fleet, err := rules.ParseFleet(fleetText)
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	touchingName, err := cmd.Flags().GetString("touching")
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	touching, err := rules.ParseTouching(strings.ToLower(touchingName))
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	salvoText, err := cmd.Flags().GetString("salvo")
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	salvo, salvoShots, err := parseSalvo(strings.ToLower(salvoText))
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	return rules.NewCustomRuleSet(boardSize, fleet, touching, salvo, salvoShots)
+}
+
+// parseSalvo parses salvo mode name or fixed number of shots
+func parseSalvo(text string) (rules.SalvoMode, int, error) {
+	switch text {
+	case rules.SalvoOff.String():
+		return rules.SalvoOff, 0, nil
+	case rules.SalvoShips.String():
+		return rules.SalvoShips, 0, nil
+	}
+
+	shots, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, 0, fmt.Errorf("bad salvo: %q", text)
+	}
+
+	return rules.SalvoFixed, shots, nil
+}
@@ -18,11 +18,14 @@ import (
 const (
 	DefaultGRPCPort    = "42284"
 	defaultStopTimeout = 4 * time.Second
+	defaultGracePeriod = 30 * time.Second
 )
 
 func BattleshipServerFlags(cmd *cobra.Command) {
 	cmd.Flags().StringP("port", "p", DefaultGRPCPort, "Battleship server port used to start server")
 	cmd.Flags().DurationP("timeout", "t", defaultStopTimeout, "Battleship server timeout duration")
+	cmd.Flags().DurationP("grace", "g", defaultGracePeriod,
+		"Time to hold disconnected player's seat, so they can reconnect and resume the game")
 }
 
 func BattleshipServerRunE(cmd *cobra.Command, _ []string) error {
@@ -38,7 +41,12 @@ func BattleshipServerRunE(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	em := server.NewEventManagerServer()
+	gracePeriod, err := cmd.Flags().GetDuration("grace")
+	if err != nil {
+		return err
+	}
+
+	em := server.NewEventManagerServer(gracePeriod)
 
 	grpcServer := grpc.NewServer()
 	api.RegisterEventManagerServer(grpcServer, em)
@@ -0,0 +1,286 @@
+package simulate
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mymmrac/battleship/ai"
+	bcmd "github.com/mymmrac/battleship/cmd"
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
+)
+
+const (
+	defaultGames         = 1000
+	histogramBucketWidth = 5
+	histogramBarWidth    = 40
+)
+
+func BattleshipSimulateFlags(cmd *cobra.Command) {
+	cmd.Flags().IntP("games", "g", defaultGames, "Number of games played by each pair of strategies")
+	cmd.Flags().StringSliceP("strategies", "s", ai.DifficultyNames(),
+		"Strategies that play against each other, single strategy plays against itself")
+	cmd.Flags().IntP("parallel", "p", runtime.NumCPU(), "Number of games played in parallel")
+	cmd.Flags().Int64("seed", 0, "Random seed used to reproduce results, random if zero")
+	bcmd.RuleSetFlags(cmd)
+}
+
+func BattleshipSimulateRunE(cmd *cobra.Command, _ []string) error {
+	games, err := cmd.Flags().GetInt("games")
+	if err != nil {
+		return err
+	}
+	if games <= 0 {
+		return errors.New("number of games must be positive")
+	}
+
+	strategyNames, err := cmd.Flags().GetStringSlice("strategies")
+	if err != nil {
+		return err
+	}
+
+	parallel, err := cmd.Flags().GetInt("parallel")
+	if err != nil {
+		return err
+	}
+	if parallel <= 0 {
+		return errors.New("number of parallel games must be positive")
+	}
+
+	seed, err := cmd.Flags().GetInt64("seed")
+	if err != nil {
+		return err
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	ruleSet, err := bcmd.RuleSetFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	difficulties := make([]ai.Difficulty, 0, len(strategyNames))
+	for _, name := range strategyNames {
+		var difficulty ai.Difficulty
+		difficulty, err = ai.ParseDifficulty(name)
+		if err != nil {
+			return err
+		}
+		difficulties = append(difficulties, difficulty)
+	}
+
+	var matchUps [][2]ai.Difficulty
+	switch len(difficulties) {
+	case 0:
+		return errors.New("no strategies")
+	case 1:
+		matchUps = append(matchUps, [2]ai.Difficulty{difficulties[0], difficulties[0]})
+	default:
+		for i := range difficulties {
+			for j := i + 1; j < len(difficulties); j++ {
+				matchUps = append(matchUps, [2]ai.Difficulty{difficulties[i], difficulties[j]})
+			}
+		}
+	}
+
+	fmt.Println("Seed:", seed)
+	fmt.Println("Rules:", ruleSet)
+
+	for i, matchUp := range matchUps {
+		start := time.Now()
+
+		// Each game gets its own seed, so results do not depend on the order games are played in
+		var results [2]sideStats
+		results, err = simulate(matchUp, ruleSet, games, parallel, seed+int64(i*games))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n%s vs %s: %d games in %s\n", matchUp[0], matchUp[1], games, time.Since(start).Round(time.Millisecond))
+		for side, result := range results {
+			printStats(matchUp[side], result, games)
+		}
+	}
+
+	return nil
+}
+
+// sideStats contains results of one side of match-up
+type sideStats struct {
+	wins        int
+	shotsToWin  []int
+	totalShots  int
+	gamesPlayed int
+}
+
+type gameResult struct {
+	winner int
+	shots  [2]int
+	err    error
+}
+
+// simulate plays games between two strategies in parallel, sides take turns to shoot first
+func simulate(matchUp [2]ai.Difficulty, ruleSet rules.RuleSet, games, parallel int, seed int64) ([2]sideStats, error) {
+	jobs := make(chan int)
+	results := make(chan gameResult)
+
+	wg := sync.WaitGroup{}
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for game := range jobs {
+				rng := rand.New(rand.NewSource(seed + int64(game)))
+				strategies := [2]ai.Strategy{
+					ai.NewStrategy(matchUp[0], ruleSet, rng),
+					ai.NewStrategy(matchUp[1], ruleSet, rng),
+				}
+
+				winner, shots, err := playGame(strategies, ruleSet, game%2)
+				results <- gameResult{winner: winner, shots: shots, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for game := 0; game < games; game++ {
+			jobs <- game
+		}
+		close(jobs)
+
+		wg.Wait()
+		close(results)
+	}()
+
+	var stats [2]sideStats
+	var err error
+	for result := range results {
+		if result.err != nil {
+			err = result.err
+			continue
+		}
+
+		for side := range stats {
+			stats[side].gamesPlayed++
+			stats[side].totalShots += result.shots[side]
+		}
+
+		stats[result.winner].wins++
+		stats[result.winner].shotsToWin = append(stats[result.winner].shotsToWin, result.shots[result.winner])
+	}
+
+	return stats, err
+}
+
+// playGame plays single game until one of the fleets is destroyed, returns winner and number of shots of each side
+func playGame(strategies [2]ai.Strategy, ruleSet rules.RuleSet, first int) (int, [2]int, error) {
+	var boards [2]*rules.Board
+	for side, strategy := range strategies {
+		boards[side] = rules.NewBoard(ruleSet)
+		if err := strategy.PlaceFleet(boards[side]); err != nil {
+			return 0, [2]int{}, fmt.Errorf("place fleet: %w", err)
+		}
+
+		if err := boards[side].Validate(); err != nil {
+			return 0, [2]int{}, fmt.Errorf("invalid fleet placement: %w", err)
+		}
+	}
+
+	var shots [2]int
+	turn := first
+	for {
+		opponent := 1 - turn
+
+		var targets []data.Point[int]
+		if ruleSet.Salvo == rules.SalvoOff {
+			targets = []data.Point[int]{strategies[turn].NextShot()}
+		} else {
+			targets = strategies[turn].NextShots(rules.ShotsPerTurn(boards[turn], boards[opponent]))
+		}
+
+		missed := false
+		for _, pos := range targets {
+			result, err := boards[opponent].Shoot(pos)
+			if err != nil {
+				return 0, shots, fmt.Errorf("invalid shot at %d:%d: %w", pos.X, pos.Y, err)
+			}
+			strategies[turn].Observe(pos, result)
+			shots[turn]++
+
+			missed = missed || result == rules.ShotMiss
+		}
+
+		if !boards[opponent].HasAlive() {
+			return turn, shots, nil
+		}
+
+		// Turn stays with shooter until miss, in salvo game turn goes to opponent after each salvo
+		if missed || ruleSet.Salvo != rules.SalvoOff {
+			turn = opponent
+		}
+	}
+}
+
+func printStats(difficulty ai.Difficulty, stats sideStats, games int) {
+	averageShots := 0.0
+	if stats.gamesPlayed > 0 {
+		averageShots = float64(stats.totalShots) / float64(stats.gamesPlayed)
+	}
+
+	averageShotsToWin := 0.0
+	if stats.wins > 0 {
+		sum := 0
+		for _, shots := range stats.shotsToWin {
+			sum += shots
+		}
+		averageShotsToWin = float64(sum) / float64(stats.wins)
+	}
+
+	fmt.Printf("  %-8s wins %6d (%5.1f%%), avg shots to win %5.1f, avg shots %5.1f\n",
+		difficulty, stats.wins, float64(stats.wins)*100/float64(games), averageShotsToWin, averageShots)
+
+	if stats.wins > 0 {
+		printHistogram(stats.shotsToWin)
+	}
+}
+
+// printHistogram prints distribution of shots to win grouped into buckets
+func printHistogram(values []int) {
+	minValue, maxValue := values[0], values[0]
+	for _, value := range values {
+		if value < minValue {
+			minValue = value
+		}
+		if value > maxValue {
+			maxValue = value
+		}
+	}
+
+	firstBucket := minValue / histogramBucketWidth
+	buckets := make([]int, maxValue/histogramBucketWidth-firstBucket+1)
+	for _, value := range values {
+		buckets[value/histogramBucketWidth-firstBucket]++
+	}
+
+	largestBucket := 0
+	for _, count := range buckets {
+		if count > largestBucket {
+			largestBucket = count
+		}
+	}
+
+	for i, count := range buckets {
+		from := (firstBucket + i) * histogramBucketWidth
+		bar := strings.Repeat("#", count*histogramBarWidth/largestBucket)
+		fmt.Printf("    %3d-%-3d %6d %s\n", from, from+histogramBucketWidth-1, count, bar)
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mymmrac/battleship/ai"
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server/api"
+)
+
+const (
+	computerActionsBuffer = 4
+	computerShotDelay     = 600 * time.Millisecond
+)
+
+// errGameEnded is returned by computer's event handler when the match is over, it's not a disconnect
+var errGameEnded = errors.New("game ended")
+
+// computerAction is a step of the match executed by computer's event handler, error ends the match
+type computerAction func(gameEvents chan<- events.GameEvent) error
+
+// ComputerOpponent is an in-process opponent that plays the match without server, it produces the same events as
+// server does
+type ComputerOpponent struct {
+	strategy ai.Strategy
+	ruleSet  rules.RuleSet
+
+	board       *rules.Board
+	playerBoard *rules.Board
+
+	actions chan computerAction
+	done    chan struct{}
+}
+
+func NewComputerOpponent(strategy ai.Strategy, ruleSet rules.RuleSet) (*ComputerOpponent, error) {
+	board := rules.NewBoard(ruleSet)
+	if err := strategy.PlaceFleet(board); err != nil {
+		return nil, fmt.Errorf("place computer fleet: %w", err)
+	}
+
+	return &ComputerOpponent{
+		strategy: strategy,
+		ruleSet:  ruleSet,
+		board:    board,
+		actions:  make(chan computerAction, computerActionsBuffer),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Ready accepts player's fleet, computer is always ready right after the player, so the player shoots first
+func (c *ComputerOpponent) Ready(ships [][]data.Point[int]) error {
+	return c.do(func(gameEvents chan<- events.GameEvent) error {
+		board := rules.NewBoard(c.ruleSet)
+		if err := board.PlaceFleet(ships); err != nil {
+			sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_Error{
+				Error: &api.Error{Message: fmt.Sprintf("invalid fleet: %s", err)},
+			}})
+			return nil
+		}
+		c.playerBoard = board
+
+		sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_Ready{Ready: &api.Ready{Ready: true}}})
+		sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_GameStarted{GameStarted: &api.GameStarted{
+			MyTurn: true,
+			Shots:  int32(rules.ShotsPerTurn(c.playerBoard, c.board)),
+		}}})
+		return nil
+	})
+}
+
+func (c *ComputerOpponent) NotReady() error {
+	return c.do(func(_ chan<- events.GameEvent) error {
+		c.playerBoard = nil
+		return nil
+	})
+}
+
+func (c *ComputerOpponent) Shoot(pos data.Point[int]) error {
+	return c.do(func(gameEvents chan<- events.GameEvent) error {
+		if c.playerBoard == nil || !c.playerBoard.HasAlive() || !c.board.HasAlive() ||
+			c.ruleSet.Salvo != rules.SalvoOff {
+			sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_Error{
+				Error: &api.Error{Message: "can't shoot now"},
+			}})
+			return nil
+		}
+
+		result, err := c.board.Shoot(pos)
+		if err != nil {
+			sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_Error{Error: &api.Error{Message: err.Error()}}})
+			return nil
+		}
+
+		sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_ShotResult{ShotResult: &api.ShotResult{
+			Pos:     events.PointToGRPC(pos),
+			Outcome: events.ShotResultToGRPC(result),
+		}}})
+
+		if !c.board.HasAlive() {
+			return c.endGame(gameEvents, true)
+		}
+
+		if result == rules.ShotMiss {
+			return c.play(gameEvents)
+		}
+		return nil
+	})
+}
+
+// play makes computer's shots until it misses or wins
+func (c *ComputerOpponent) play(gameEvents chan<- events.GameEvent) error {
+	for {
+		time.Sleep(computerShotDelay)
+
+		pos := c.strategy.NextShot()
+		result, err := c.playerBoard.Shoot(pos)
+		if err != nil {
+			return fmt.Errorf("computer shot at %d:%d: %w", pos.X, pos.Y, err)
+		}
+		c.strategy.Observe(pos, result)
+
+		sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_ShotResult{ShotResult: &api.ShotResult{
+			Pos:        events.PointToGRPC(pos),
+			Outcome:    events.ShotResultToGRPC(result),
+			ByOpponent: true,
+		}}})
+
+		if !c.playerBoard.HasAlive() {
+			return c.endGame(gameEvents, false)
+		}
+
+		if result == rules.ShotMiss {
+			return nil
+		}
+	}
+}
+
+// Salvo accepts player's salvo, after that computer fires its salvo
+func (c *ComputerOpponent) Salvo(targets []data.Point[int]) error {
+	return c.do(func(gameEvents chan<- events.GameEvent) error {
+		if c.playerBoard == nil || !c.playerBoard.HasAlive() || !c.board.HasAlive() ||
+			c.ruleSet.Salvo == rules.SalvoOff {
+			sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_Error{
+				Error: &api.Error{Message: "can't shoot now"},
+			}})
+			return nil
+		}
+
+		if err := rules.ValidateSalvo(c.playerBoard, c.board, targets); err != nil {
+			sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_Error{Error: &api.Error{Message: err.Error()}}})
+			return nil
+		}
+
+		results := make([]*api.ShotResult, 0, len(targets))
+		for _, pos := range targets {
+			result, err := c.board.Shoot(pos)
+			if err != nil {
+				return fmt.Errorf("player shot at %d:%d: %w", pos.X, pos.Y, err)
+			}
+
+			results = append(results, &api.ShotResult{
+				Pos:     events.PointToGRPC(pos),
+				Outcome: events.ShotResultToGRPC(result),
+			})
+		}
+
+		sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_SalvoResult{
+			SalvoResult: &api.SalvoResult{Results: results},
+		}})
+
+		if !c.board.HasAlive() {
+			return c.endGame(gameEvents, true)
+		}
+
+		return c.playSalvo(gameEvents)
+	})
+}
+
+// playSalvo makes computer's salvo, after that turn goes back to the player
+func (c *ComputerOpponent) playSalvo(gameEvents chan<- events.GameEvent) error {
+	time.Sleep(computerShotDelay)
+
+	targets := c.strategy.NextShots(rules.ShotsPerTurn(c.board, c.playerBoard))
+	results := make([]*api.ShotResult, 0, len(targets))
+	for _, pos := range targets {
+		result, err := c.playerBoard.Shoot(pos)
+		if err != nil {
+			return fmt.Errorf("computer shot at %d:%d: %w", pos.X, pos.Y, err)
+		}
+		c.strategy.Observe(pos, result)
+
+		results = append(results, &api.ShotResult{
+			Pos:        events.PointToGRPC(pos),
+			Outcome:    events.ShotResultToGRPC(result),
+			ByOpponent: true,
+		})
+	}
+
+	won := !c.playerBoard.HasAlive()
+
+	shots := 0
+	if !won {
+		shots = rules.ShotsPerTurn(c.playerBoard, c.board)
+	}
+
+	sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_SalvoResult{SalvoResult: &api.SalvoResult{
+		Results: results,
+		Shots:   int32(shots),
+	}}})
+
+	if won {
+		return c.endGame(gameEvents, false)
+	}
+	return nil
+}
+
+// HandleGameEvents runs computer's actions until the match ends, returns errGameEnded after the end of the match or
+// an error if computer can't continue the match
+func (c *ComputerOpponent) HandleGameEvents(gameEvents chan<- events.GameEvent) error {
+	defer close(c.done)
+
+	for {
+		action := <-c.actions
+		if err := action(gameEvents); err != nil {
+			return err
+		}
+	}
+}
+
+// do queues the action for computer's event handler, actions are rejected after the match ended
+func (c *ComputerOpponent) do(action computerAction) error {
+	select {
+	case c.actions <- action:
+		return nil
+	case <-c.done:
+		return errGameEnded
+	}
+}
+
+func sendComputerEvent(gameEvents chan<- events.GameEvent, event *api.Event) {
+	gameEvents <- events.NewServerEvent(event)
+}
+
+func difficultyText(difficulty ai.Difficulty) string {
+	return "AI: " + difficulty.String()
+}
+
+// nextDifficulty returns the next harder difficulty, after the hardest goes the easiest one
+func nextDifficulty(difficulty ai.Difficulty) ai.Difficulty {
+	for i, d := range ai.Difficulties {
+		if d == difficulty {
+			return ai.Difficulties[(i+1)%len(ai.Difficulties)]
+		}
+	}
+
+	return ai.DefaultDifficulty
+}
+
+// endGame sends event about the end of the match for the player, computer's fleet is revealed
+func (c *ComputerOpponent) endGame(gameEvents chan<- events.GameEvent, won bool) error {
+	sendComputerEvent(gameEvents, &api.Event{Payload: &api.Event_GameEnded{GameEnded: &api.GameEnded{
+		Won:           won,
+		OpponentShips: events.PointsToGRPC(c.board.FleetCells()),
+	}}})
+	return errGameEnded
+}
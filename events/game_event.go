@@ -1,6 +1,6 @@
 package events
 
-import "github.com/mymmrac/battleship/data"
+import "github.com/mymmrac/battleship/rules"
 
 type GameEventType int
 
@@ -11,13 +11,9 @@ const (
 	GameEventNewGameStartFailed
 	GameEventJoinedGame
 	GameEventJoinGameFailed
-	GameEventPlayerReady
-	GameEventPlayerNotReady
-	GameEventShoot
-	GameEventMiss
-	GameEventHit
-	GameEventDestroyed
-	GameEventGameEnded
+	GameEventGameListUpdated
+	GameEventConnectionLost
+	GameEventDisconnected
 )
 
 type GameEvent interface {
@@ -54,18 +50,50 @@ func (e GameEventSignal) EventType() GameEventType {
 	return e.Type
 }
 
-type GameEventCoord struct {
+type GameEventGameList struct {
+	Type  GameEventType
+	Games []GameInfo
+}
+
+func NewGameEventGameList(games []GameInfo) GameEventGameList {
+	return GameEventGameList{
+		Type:  GameEventGameListUpdated,
+		Games: games,
+	}
+}
+
+func (e GameEventGameList) EventType() GameEventType {
+	return e.Type
+}
+
+type GameEventNewGame struct {
 	Type GameEventType
-	Pos  data.Point[int]
+	Code string
+}
+
+func NewGameEventNewGame(code string) GameEventNewGame {
+	return GameEventNewGame{
+		Type: GameEventNewGameStarted,
+		Code: code,
+	}
+}
+
+func (e GameEventNewGame) EventType() GameEventType {
+	return e.Type
+}
+
+type GameEventJoined struct {
+	Type    GameEventType
+	RuleSet rules.RuleSet
 }
 
-func NewGameEventCoord(pos data.Point[int]) GameEventCoord {
-	return GameEventCoord{
-		Type: GameEventShoot,
-		Pos:  pos,
+func NewGameEventJoined(ruleSet rules.RuleSet) GameEventJoined {
+	return GameEventJoined{
+		Type:    GameEventJoinedGame,
+		RuleSet: ruleSet,
 	}
 }
 
-func (e GameEventCoord) EventType() GameEventType {
+func (e GameEventJoined) EventType() GameEventType {
 	return e.Type
 }
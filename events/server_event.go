@@ -1,43 +1,302 @@
 package events
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/google/uuid"
 
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
 	"github.com/mymmrac/battleship/server/api"
 )
 
-type ServerEventType int
-
-const (
-	_ ServerEventType = iota
-	ServerEventNewGame
-	ServerEventListGames
-	ServerEventJoinGame
-	ServerEventGameEvent
-)
-
 type ServerEvent struct {
-	Type ServerEventType
-	From uuid.UUID
-	Data []byte
+	Event *api.Event
+}
+
+func NewServerEvent(event *api.Event) ServerEvent {
+	return ServerEvent{
+		Event: event,
+	}
 }
 
 func (e ServerEvent) EventType() GameEventType {
 	return GameEventFromServer
 }
 
-func ServerEventFromGRPC(grpcEvent *api.Event) ServerEvent {
-	return ServerEvent{
-		Type: ServerEventType(grpcEvent.Type),
-		From: uuid.Must(uuid.FromBytes(grpcEvent.From.Value)),
-		Data: grpcEvent.Data,
+type GameInfo struct {
+	ID        uuid.UUID
+	HostName  string
+	CreatedAt time.Time
+	RuleSet   string
+}
+
+func GameInfoFromGRPC(info *api.GameInfo) (GameInfo, error) {
+	id, err := UUIDFromGRPC(info.GetId())
+	if err != nil {
+		return GameInfo{}, err
+	}
+
+	return GameInfo{
+		ID:        id,
+		HostName:  info.GetHostName(),
+		CreatedAt: info.GetCreatedAt().AsTime(),
+		RuleSet:   info.GetRuleSet(),
+	}, nil
+}
+
+func UUIDToGRPC(id uuid.UUID) *api.UUID {
+	return &api.UUID{Value: id[:]}
+}
+
+func UUIDFromGRPC(id *api.UUID) (uuid.UUID, error) {
+	return uuid.FromBytes(id.GetValue())
+}
+
+func PointToGRPC(pos data.Point[int]) *api.Point {
+	return &api.Point{
+		X: int32(pos.X),
+		Y: int32(pos.Y),
+	}
+}
+
+func PointFromGRPC(pos *api.Point) data.Point[int] {
+	return data.NewPoint(int(pos.GetX()), int(pos.GetY()))
+}
+
+func PointsToGRPC(points []data.Point[int]) []*api.Point {
+	grpcPoints := make([]*api.Point, 0, len(points))
+	for _, pos := range points {
+		grpcPoints = append(grpcPoints, PointToGRPC(pos))
+	}
+	return grpcPoints
+}
+
+func PointsFromGRPC(grpcPoints []*api.Point) []data.Point[int] {
+	points := make([]data.Point[int], 0, len(grpcPoints))
+	for _, pos := range grpcPoints {
+		points = append(points, PointFromGRPC(pos))
+	}
+	return points
+}
+
+func ShipsToGRPC(ships [][]data.Point[int]) []*api.Ship {
+	grpcShips := make([]*api.Ship, 0, len(ships))
+	for _, ship := range ships {
+		grpcShips = append(grpcShips, &api.Ship{Cells: PointsToGRPC(ship)})
+	}
+	return grpcShips
+}
+
+func ShipsFromGRPC(grpcShips []*api.Ship) [][]data.Point[int] {
+	ships := make([][]data.Point[int], 0, len(grpcShips))
+	for _, ship := range grpcShips {
+		ships = append(ships, PointsFromGRPC(ship.GetCells()))
+	}
+	return ships
+}
+
+func ShotResultToGRPC(result rules.ShotResult) api.ShotOutcome {
+	switch result {
+	case rules.ShotMiss:
+		return api.ShotOutcome_SHOT_OUTCOME_MISS
+	case rules.ShotHit:
+		return api.ShotOutcome_SHOT_OUTCOME_HIT
+	case rules.ShotDestroyed:
+		return api.ShotOutcome_SHOT_OUTCOME_DESTROYED
+	default:
+		panic(fmt.Sprintf("unknown shot result: %d", result))
+	}
+}
+
+func ShotResultFromGRPC(outcome api.ShotOutcome) (rules.ShotResult, error) {
+	switch outcome {
+	case api.ShotOutcome_SHOT_OUTCOME_MISS:
+		return rules.ShotMiss, nil
+	case api.ShotOutcome_SHOT_OUTCOME_HIT:
+		return rules.ShotHit, nil
+	case api.ShotOutcome_SHOT_OUTCOME_DESTROYED:
+		return rules.ShotDestroyed, nil
+	default:
+		return 0, fmt.Errorf("unknown shot outcome: %s", outcome)
+	}
+}
+
+// BoardToGRPC converts board cells, if hideShips is set cells with not hit ships are converted as empty
+func BoardToGRPC(board *rules.Board, hideShips bool) *api.Board {
+	grpcBoard := &api.Board{
+		Width:  int32(board.Width()),
+		Height: int32(board.Height()),
+		Cells:  make([]api.Cell, board.Width()*board.Height()),
+	}
+
+	for y := 0; y < board.Height(); y++ {
+		for x := 0; x < board.Width(); x++ {
+			cell := board.At(data.Point[int]{X: x, Y: y})
+			if hideShips && cell == rules.CellShip {
+				cell = rules.CellEmpty
+			}
+			grpcBoard.Cells[y*board.Width()+x] = cellToGRPC(cell)
+		}
+	}
+
+	return grpcBoard
+}
+
+// BoardFromGRPC replaces all cells of the board with converted ones, boards must be of the same size
+func BoardFromGRPC(grpcBoard *api.Board, board *rules.Board) error {
+	if int(grpcBoard.GetWidth()) != board.Width() || int(grpcBoard.GetHeight()) != board.Height() ||
+		len(grpcBoard.GetCells()) != board.Width()*board.Height() {
+		return fmt.Errorf("bad board size: %dx%d, expected %dx%d",
+			grpcBoard.GetWidth(), grpcBoard.GetHeight(), board.Width(), board.Height())
+	}
+
+	cells := make([]rules.Cell, len(grpcBoard.GetCells()))
+	for i, grpcCell := range grpcBoard.GetCells() {
+		cell, err := cellFromGRPC(grpcCell)
+		if err != nil {
+			return err
+		}
+		cells[i] = cell
+	}
+
+	for i, cell := range cells {
+		board.Set(data.Point[int]{X: i % board.Width(), Y: i / board.Width()}, cell)
+	}
+
+	return nil
+}
+
+func RuleSetToGRPC(ruleSet rules.RuleSet) *api.RuleSet {
+	fleet := make([]int32, 0, len(ruleSet.Fleet))
+	for _, count := range ruleSet.Fleet {
+		fleet = append(fleet, int32(count))
+	}
+
+	return &api.RuleSet{
+		Name:       ruleSet.Name,
+		Width:      int32(ruleSet.Width),
+		Height:     int32(ruleSet.Height),
+		Fleet:      fleet,
+		Touching:   touchingToGRPC(ruleSet.Touching),
+		Salvo:      salvoModeToGRPC(ruleSet.Salvo),
+		SalvoShots: int32(ruleSet.SalvoShots),
+	}
+}
+
+// RuleSetFromGRPC converts and validates rule set
+func RuleSetFromGRPC(grpcRuleSet *api.RuleSet) (rules.RuleSet, error) {
+	fleet := make(rules.Fleet, 0, len(grpcRuleSet.GetFleet()))
+	for _, count := range grpcRuleSet.GetFleet() {
+		fleet = append(fleet, int(count))
+	}
+
+	touching, err := touchingFromGRPC(grpcRuleSet.GetTouching())
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	salvo, err := salvoModeFromGRPC(grpcRuleSet.GetSalvo())
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	ruleSet := rules.RuleSet{
+		Name:       grpcRuleSet.GetName(),
+		Width:      int(grpcRuleSet.GetWidth()),
+		Height:     int(grpcRuleSet.GetHeight()),
+		Fleet:      fleet,
+		Touching:   touching,
+		Salvo:      salvo,
+		SalvoShots: int(grpcRuleSet.GetSalvoShots()),
+	}
+
+	if err := ruleSet.Validate(); err != nil {
+		return rules.RuleSet{}, fmt.Errorf("invalid rule set: %w", err)
+	}
+
+	return ruleSet, nil
+}
+
+func cellToGRPC(cell rules.Cell) api.Cell {
+	switch cell {
+	case rules.CellEmpty:
+		return api.Cell_CELL_EMPTY
+	case rules.CellShip:
+		return api.Cell_CELL_SHIP
+	case rules.CellMiss:
+		return api.Cell_CELL_MISS
+	case rules.CellShipHit:
+		return api.Cell_CELL_SHIP_HIT
+	default:
+		panic(fmt.Sprintf("unknown cell: %d", cell))
+	}
+}
+
+func cellFromGRPC(cell api.Cell) (rules.Cell, error) {
+	switch cell {
+	case api.Cell_CELL_EMPTY:
+		return rules.CellEmpty, nil
+	case api.Cell_CELL_SHIP:
+		return rules.CellShip, nil
+	case api.Cell_CELL_MISS:
+		return rules.CellMiss, nil
+	case api.Cell_CELL_SHIP_HIT:
+		return rules.CellShipHit, nil
+	default:
+		return 0, fmt.Errorf("unknown cell: %s", cell)
+	}
+}
+
+func touchingToGRPC(touching rules.Touching) api.Touching {
+	switch touching {
+	case rules.TouchingNone:
+		return api.Touching_TOUCHING_NONE
+	case rules.TouchingCorners:
+		return api.Touching_TOUCHING_CORNERS
+	case rules.TouchingFree:
+		return api.Touching_TOUCHING_FREE
+	default:
+		panic(fmt.Sprintf("unknown touching rule: %d", touching))
+	}
+}
+
+func touchingFromGRPC(touching api.Touching) (rules.Touching, error) {
+	switch touching {
+	case api.Touching_TOUCHING_NONE:
+		return rules.TouchingNone, nil
+	case api.Touching_TOUCHING_CORNERS:
+		return rules.TouchingCorners, nil
+	case api.Touching_TOUCHING_FREE:
+		return rules.TouchingFree, nil
+	default:
+		return 0, fmt.Errorf("unknown touching rule: %s", touching)
+	}
+}
+
+func salvoModeToGRPC(salvo rules.SalvoMode) api.SalvoMode {
+	switch salvo {
+	case rules.SalvoOff:
+		return api.SalvoMode_SALVO_MODE_OFF
+	case rules.SalvoFixed:
+		return api.SalvoMode_SALVO_MODE_FIXED
+	case rules.SalvoShips:
+		return api.SalvoMode_SALVO_MODE_SHIPS
+	default:
+		panic(fmt.Sprintf("unknown salvo mode: %d", salvo))
 	}
 }
 
-func (e ServerEvent) ToGRPC() *api.Event {
-	return &api.Event{
-		Type: int32(e.Type),
-		From: &api.UUID{Value: e.From[:]},
-		Data: e.Data,
+func salvoModeFromGRPC(salvo api.SalvoMode) (rules.SalvoMode, error) {
+	switch salvo {
+	case api.SalvoMode_SALVO_MODE_OFF:
+		return rules.SalvoOff, nil
+	case api.SalvoMode_SALVO_MODE_FIXED:
+		return rules.SalvoFixed, nil
+	case api.SalvoMode_SALVO_MODE_SHIPS:
+		return rules.SalvoShips, nil
+	default:
+		return 0, fmt.Errorf("unknown salvo mode: %s", salvo)
 	}
 }
@@ -34,3 +34,35 @@ func loadFace(filename string, size float64) (font.Face, error) {
 
 	return fontFace, nil
 }
+
+// cellFontRatio is a size of font relative to the cell it's drawn in
+const cellFontRatio = 0.6
+
+// CellFaces holds faces used for text in cells, one for each whole cell size
+type CellFaces map[int]font.Face
+
+func loadCellFaces(filename string) (CellFaces, error) {
+	faces := CellFaces{}
+	for size := int(minCellSize); size <= int(maxCellSize); size++ {
+		fontFace, err := loadFace(filename, float64(size)*cellFontRatio)
+		if err != nil {
+			return nil, err
+		}
+
+		faces[size] = fontFace
+	}
+
+	return faces, nil
+}
+
+// For returns face that fits cell of size
+func (f CellFaces) For(cellSize float32) font.Face {
+	size := int(cellSize)
+	if size < int(minCellSize) {
+		size = int(minCellSize)
+	} else if size > int(maxCellSize) {
+		size = int(maxCellSize)
+	}
+
+	return f[size]
+}
@@ -5,15 +5,24 @@ import (
 	"image"
 	"image/color"
 	"math"
+	"math/rand"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"google.golang.org/grpc"
 
+	"github.com/mymmrac/battleship/ai"
+	"github.com/mymmrac/battleship/client"
 	"github.com/mymmrac/battleship/data"
 	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/replay"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server"
 	"github.com/mymmrac/battleship/ui"
 )
 
@@ -28,20 +37,65 @@ type Game struct {
 
 	serverAddr string
 	serverPort string
+	playerName string
 
-	grpcConn     *grpc.ClientConn
-	eventManager *EventManagerClient
+	// settings are stored settings, flags given on start override them only for current session
+	settings Settings
 
-	events chan events.GameEvent
+	// window is the last seen window geometry, it's stored once it stays the same for windowSaveDelay ticks
+	window          WindowSettings
+	windowSaveTicks int
 
-	currentScene *Scene
-	scenes       map[SceneID]*Scene
+	grpcConn     *grpc.ClientConn
+	eventManager *client.EventManagerClient
+	opponent     Opponent
 
-	newGameBtn  *ui.Button
-	joinGameBtn *ui.Button
-	exitBtn     *ui.Button
+	events chan events.GameEvent
 
+	currentScene   *Scene
+	currentSceneID SceneID
+	scenes         map[SceneID]*Scene
+
+	newGameBtn      *ui.Button
+	privateGameBtn  *ui.Button
+	joinGameBtn     *ui.Button
+	watchGameBtn    *ui.Button
+	computerGameBtn *ui.Button
+	difficultyBtn   *ui.Button
+	ruleSetBtn      *ui.Button
+	replaysBtn      *ui.Button
+	settingsBtn     *ui.Button
+	exitBtn         *ui.Button
+
+	difficulty ai.Difficulty
+	ruleSet    rules.RuleSet
+	ruleSets   []rules.RuleSet
+
+	newGamePrivate      bool
 	newGameLoadingLabel *ui.Label
+	newGameCodeLabel    *ui.Label
+
+	lobbyLabel   *ui.Label
+	lobbyList    *ui.List
+	lobbyJoinBtn *ui.Button
+	lobbyCodeBtn *ui.Button
+	lobbyBackBtn *ui.Button
+	lobbyGames   []events.GameInfo
+	lobbyJoin    chan uuid.UUID
+	lobbyStop    chan struct{}
+	lobbyJoining bool
+
+	// lobbySpectate shows running games to watch instead of open games to join
+	lobbySpectate   bool
+	lobbyFleetsBtn  *ui.Button
+	lobbyHideFleets bool
+
+	joinCodeTitleLabel  *ui.Label
+	joinCodeInput       *ui.TextInput
+	joinCodeStatusLabel *ui.Label
+	joinCodeBtn         *ui.Button
+	joinCodeBackBtn     *ui.Button
+	joinCodeJoining     bool
 
 	myBoard    *Board
 	myShipyard *Shipyard
@@ -49,34 +103,124 @@ type Game struct {
 	readyBtn      *ui.Button
 	notReadyBtn   *ui.Button
 	clearBoardBtn *ui.Button
+	autoBoardBtn  *ui.Button
+
+	// rng is used for random fleet placement
+	rng *rand.Rand
 
 	opponentReady      bool
 	opponentReadyLabel *ui.Label
 
+	presetsLabel      *ui.Label
+	presetsList       *ui.List
+	presetNameInput   *ui.TextInput
+	presetSaveBtn     *ui.Button
+	presetLoadBtn     *ui.Button
+	presetDeleteBtn   *ui.Button
+	presetStatusLabel *ui.Label
+	presets           []FleetPreset
+
+	chatBox   *ChatBox
+	chatInput *ui.TextInput
+
 	myTurn          bool
-	lastShootPos    data.Point[int]
+	shotSent        bool
+	shots           int
 	playerTurnLabel *ui.Label
 	opponentBoard   *Board
-
-	theEndLabel *ui.Label
+	fireBtn         *ui.Button
+
+	won          bool
+	opponentLeft bool
+	disconnected bool
+	theEndLabel  *ui.Label
+
+	opponentName string
+	recording    *replay.Replay
+
+	settingsLabel       *ui.Label
+	settingsAddrLabel   *ui.Label
+	settingsAddrInput   *ui.TextInput
+	settingsPortLabel   *ui.Label
+	settingsPortInput   *ui.TextInput
+	settingsNameLabel   *ui.Label
+	settingsNameInput   *ui.TextInput
+	settingsThemeBtn    *ui.Button
+	settingsTestBtn     *ui.Button
+	settingsSaveBtn     *ui.Button
+	settingsBackBtn     *ui.Button
+	settingsStatusLabel *ui.Label
+	settingsTest        chan connectionTestResult
+
+	spectateInfoLabel *ui.Label
+	spectateLeaveBtn  *ui.Button
+	spectateHostName  string
+	spectateHostTurn  bool
+	spectateLeaving   bool
+
+	replaysLabel       *ui.Label
+	replaysList        *ui.List
+	replaysPlayBtn     *ui.Button
+	replaysBackBtn     *ui.Button
+	replaysStatusLabel *ui.Label
+	replayPaths        []string
+
+	replayLabel      *ui.Label
+	replayInfoLabel  *ui.Label
+	replayPrevBtn    *ui.Button
+	replayPauseBtn   *ui.Button
+	replayNextBtn    *ui.Button
+	replaySpeedBtn   *ui.Button
+	replayBackBtn    *ui.Button
+	playback         *replay.Playback
+	replayPaused     bool
+	replaySpeedIndex int
+	replayElapsed    time.Duration
+	// replayOnly is set when game was started only to play replay, it exits after playback
+	replayOnly bool
+
+	connectionLabel *ui.Label
 
 	objects []GameObject
 }
 
-func NewGame(serverAddr, serverPort string) (*Game, error) {
+// NewGame creates game, stored settings are used for empty strings, zero difficulty and rule set
+func NewGame(serverAddr, serverPort, playerName string, difficulty ai.Difficulty, ruleSet rules.RuleSet) (
+	*Game, error,
+) {
+	settings, err := loadSettings()
+	if err != nil {
+		fmt.Println("Load settings failed:", err)
+	}
+
+	if serverAddr == "" {
+		serverAddr = settings.ServerAddr
+	}
+	if serverPort == "" {
+		serverPort = settings.ServerPort
+	}
+	if playerName == "" {
+		playerName = settings.PlayerName
+	}
+	if difficulty == 0 {
+		difficulty = settings.difficulty()
+	}
+	if ruleSet.Name == "" {
+		ruleSet = settings.RuleSet
+	}
+
 	ebiten.SetWindowTitle("Battleship")
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
-	deviceScale := ebiten.DeviceScaleFactor()
-	windowWidth := baseWindowWidth * deviceScale
-	windowHeight := baseWindowHeight * deviceScale
-	ebiten.SetWindowSize(int(math.Ceil(windowWidth)), int(math.Ceil(windowHeight)))
+	window := settings.Window
+	if !window.onScreen() {
+		window = defaultWindow()
+	}
+	ebiten.SetWindowSize(window.Width, window.Height)
+	ebiten.SetWindowPosition(window.X, window.Y)
+	ebiten.SetFullscreen(settings.Fullscreen)
 
-	screenWidth, screenHeight := ebiten.ScreenSizeInFullscreen()
-	ebiten.SetWindowPosition(
-		int(math.Ceil((float64(screenWidth)-windowWidth)/2.0)),
-		int(math.Ceil((float64(screenHeight)-windowHeight)/2.0)),
-	)
+	ui.ApplyTheme(settings.theme())
 
 	i16, err := LoadImage(Icon16x16)
 	if err != nil {
@@ -102,48 +246,163 @@ func NewGame(serverAddr, serverPort string) (*Game, error) {
 		return nil, err
 	}
 
-	newGameBtn := ui.NewButton(data.NewPoint[float32](48, 48), 200, 40, "New Game", buttonFace)
-	joinGameBtn := ui.NewButton(data.NewPoint[float32](48, 48+40+32), 200, 40, "Join Game", buttonFace)
-	exitBtn := ui.NewButton(data.NewPoint[float32](48, 48+40*2+32*2), 200, 40, "Exit", buttonFace)
+	newGameBtn := ui.NewButton(data.NewPoint[float32](48, 48), 260, 40, "New Game", buttonFace)
+	ruleSetBtn := ui.NewButton(data.NewPoint[float32](48+260+32, 48), 240, 40, "", buttonFace)
+	privateGameBtn := ui.NewButton(data.NewPoint[float32](48, 48+40+32), 260, 40, "Private Game", buttonFace)
+	joinGameBtn := ui.NewButton(data.NewPoint[float32](48, 48+40*2+32*2), 260, 40, "Join Game", buttonFace)
+	watchGameBtn := ui.NewButton(data.NewPoint[float32](48+260+32, 48+40*2+32*2), 240, 40, "Watch Game", buttonFace)
+	computerGameBtn := ui.NewButton(data.NewPoint[float32](48, 48+40*3+32*3), 260, 40, "Play vs Computer", buttonFace)
+	difficultyBtn := ui.NewButton(data.NewPoint[float32](48+260+32, 48+40*3+32*3), 240, 40, "", buttonFace)
+	replaysBtn := ui.NewButton(data.NewPoint[float32](48, 48+40*4+32*4), 260, 40, "Replays", buttonFace)
+	settingsBtn := ui.NewButton(data.NewPoint[float32](48+260+32, 48+40*4+32*4), 240, 40, "Settings", buttonFace)
+	exitBtn := ui.NewButton(data.NewPoint[float32](48, 48+40*5+32*5), 260, 40, "Exit", buttonFace)
 
 	newGameLoadingLabel := ui.NewLabel(data.NewPoint[float32](48, 48), "", labelFace)
-
-	boardFace, err := loadFace(JetBrainsMonoFont, float64(cellSize)*0.6)
+	newGameCodeLabel := ui.NewLabel(data.NewPoint[float32](48, 48+32+32), "", labelFace)
+
+	lobbyLabel := ui.NewLabel(data.NewPoint[float32](48, 48), "Open games", labelFace)
+	lobbyList := ui.NewList(data.NewPoint[float32](48, 48+32+24), 720, 400, 40, buttonFace)
+	lobbyJoinBtn := ui.NewButton(data.NewPoint[float32](48, 48+32+24+400+32), 120, 40, "Join", buttonFace)
+	lobbyCodeBtn := ui.NewButton(data.NewPoint[float32](48+120+32, 48+32+24+400+32), 120, 40, "By Code", buttonFace)
+	lobbyBackBtn := ui.NewButton(data.NewPoint[float32](48+(120+32)*2, 48+32+24+400+32), 120, 40, "Back", buttonFace)
+	lobbyFleetsBtn := ui.NewButton(data.NewPoint[float32](48+(120+32)*3, 48+32+24+400+32), 220, 40, "", buttonFace)
+
+	joinCodeTitleLabel := ui.NewLabel(data.NewPoint[float32](48, 48), "Enter join code", labelFace)
+	joinCodeInput := ui.NewTextInput(data.NewPoint[float32](48, 48+32+24), 260, 48, labelFace)
+	joinCodeInput.SetMaxLength(server.JoinCodeLength)
+	joinCodeInput.SetPlaceholder(strings.Repeat("_", server.JoinCodeLength))
+	joinCodeInput.SetFilter(joinCodeFilter)
+	joinCodeStatusLabel := ui.NewLabel(data.NewPoint[float32](48, 48+(32+32)*2), "", buttonFace)
+	joinCodeBtn := ui.NewButton(data.NewPoint[float32](48, 48+(32+32)*3), 120, 40, "Join", buttonFace)
+	joinCodeBackBtn := ui.NewButton(data.NewPoint[float32](48+120+32, 48+(32+32)*3), 120, 40, "Back", buttonFace)
+
+	cellFaces, err := loadCellFaces(JetBrainsMonoFont)
 	if err != nil {
 		return nil, err
 	}
 
-	myBoard := NewBoard(data.NewPoint[float32](48, 48), boardFace)
-	myShipyard := NewShipyard(data.NewPoint[float32](48, 440), myBoard, boardFace)
-	opponentBoard := NewBoard(data.NewPoint[float32](48+400, 48), boardFace)
+	myBoard := NewBoard(data.NewPoint[float32](48, 48), ruleSet, cellFaces)
+	myShipyard := NewShipyard(data.NewPoint[float32](48, 440), myBoard, cellFaces)
+	opponentBoard := NewBoard(data.NewPoint[float32](48+400, 48), ruleSet, cellFaces)
 
 	readyBtn := ui.NewButton(data.NewPoint[float32](48, 570), 120, 40, "Ready", buttonFace)
 	notReadyBtn := ui.NewButton(data.NewPoint[float32](48, 570), 160, 40, "Not Ready", buttonFace)
 	clearBoardBtn := ui.NewButton(data.NewPoint[float32](48+120+32, 570), 120, 40, "Clear", buttonFace)
+	autoBoardBtn := ui.NewButton(data.NewPoint[float32](48+(120+32)*2, 570), 120, 40, "Auto", buttonFace)
 	opponentReadyLabel := ui.NewLabel(data.NewPoint[float32](48, 640), "Opponent: not ready", labelFace)
 
+	presetsLabel := ui.NewLabel(data.NewPoint[float32](48+400, 48), "Presets", labelFace)
+	presetsList := ui.NewList(data.NewPoint[float32](48+400, 48+32+24), 584, 280, 40, buttonFace)
+	presetsList.SetEmptyText("No saved presets")
+	presetNameInput := ui.NewTextInput(data.NewPoint[float32](48+400, 48+32+24+280+8), 584, 40, buttonFace)
+	presetNameInput.SetMaxLength(maxPresetNameLen)
+	presetNameInput.SetPlaceholder("Preset name")
+	presetNameInput.SetFilter(presetNameFilter)
+	presetSaveBtn := ui.NewButton(data.NewPoint[float32](48+400, 440), 120, 40, "Save", buttonFace)
+	presetLoadBtn := ui.NewButton(data.NewPoint[float32](48+400+120+32, 440), 120, 40, "Load", buttonFace)
+	presetDeleteBtn := ui.NewButton(data.NewPoint[float32](48+400+(120+32)*2, 440), 120, 40, "Delete", buttonFace)
+	presetStatusLabel := ui.NewLabel(data.NewPoint[float32](48+400, 440+40+24), "", buttonFace)
+
+	chatFace, err := loadFace(JetBrainsMonoFont, 18)
+	if err != nil {
+		return nil, err
+	}
+
+	chatBox := NewChatBox(data.NewPoint[float32](48+400, 552), 584, 104, chatFace)
+	chatBox.SetEmptyText("Chat with your opponent")
+	chatInput := ui.NewTextInput(data.NewPoint[float32](48+400, 552+104+8), 584, 40, chatFace)
+	chatInput.SetMaxLength(server.MaxChatMessageLen)
+
 	playerTurnLabel := ui.NewLabel(data.NewPoint[float32](48+400+48/2, 440), "", labelFace)
 	playerTurnLabel.SetAlignment(ui.LabelAlignmentTopCenter)
 
+	fireBtn := ui.NewButton(data.NewPoint[float32](48+400+48/2-60, 440+32+32), 120, 40, "Fire", buttonFace)
+
 	theEndLabel := ui.NewLabel(data.NewPoint[float32](48+400+48/2, 440), "", labelFace)
 	theEndLabel.SetAlignment(ui.LabelAlignmentTopCenter)
 
+	replaysLabel := ui.NewLabel(data.NewPoint[float32](48, 48), "Replays", labelFace)
+	replaysList := ui.NewList(data.NewPoint[float32](48, 48+32+24), 720, 400, 40, buttonFace)
+	replaysList.SetEmptyText("No replays yet")
+	replaysPlayBtn := ui.NewButton(data.NewPoint[float32](48, 48+32+24+400+32), 120, 40, "Play", buttonFace)
+	replaysBackBtn := ui.NewButton(data.NewPoint[float32](48+120+32, 48+32+24+400+32), 120, 40, "Back", buttonFace)
+	replaysStatusLabel := ui.NewLabel(data.NewPoint[float32](48, 48+32+24+400+32+40+24), "", buttonFace)
+
+	replayLabel := ui.NewLabel(data.NewPoint[float32](48, 440), "", labelFace)
+	replayInfoLabel := ui.NewLabel(data.NewPoint[float32](48, 440+32+16), "", buttonFace)
+	replayPrevBtn := ui.NewButton(data.NewPoint[float32](48, 570), 60, 40, "<", buttonFace)
+	replayPauseBtn := ui.NewButton(data.NewPoint[float32](48+60+16, 570), 120, 40, "", buttonFace)
+	replayNextBtn := ui.NewButton(data.NewPoint[float32](48+60+16+120+16, 570), 60, 40, ">", buttonFace)
+	replaySpeedBtn := ui.NewButton(data.NewPoint[float32](48+(60+16)*2+120+16, 570), 100, 40, "", buttonFace)
+	replayBackBtn := ui.NewButton(data.NewPoint[float32](48+(60+16)*2+120+16+100+32, 570), 120, 40, "Back",
+		buttonFace)
+
+	settingsLabel := ui.NewLabel(data.NewPoint[float32](48, 48), "Settings", labelFace)
+	settingsAddrLabel := ui.NewLabel(data.NewPoint[float32](48, 112), "Server address", buttonFace)
+	settingsAddrInput := ui.NewTextInput(data.NewPoint[float32](48, 144), 400, 40, buttonFace)
+	settingsAddrInput.SetPlaceholder(defaultServerAddr)
+	settingsPortLabel := ui.NewLabel(data.NewPoint[float32](48+400+32, 112), "Port", buttonFace)
+	settingsPortInput := ui.NewTextInput(data.NewPoint[float32](48+400+32, 144), 160, 40, buttonFace)
+	settingsPortInput.SetMaxLength(5)
+	settingsPortInput.SetFilter(portFilter)
+	settingsNameLabel := ui.NewLabel(data.NewPoint[float32](48, 216), "Player name", buttonFace)
+	settingsNameInput := ui.NewTextInput(data.NewPoint[float32](48, 248), 400, 40, buttonFace)
+	settingsNameInput.SetMaxLength(maxPlayerNameLen)
+	settingsThemeBtn := ui.NewButton(data.NewPoint[float32](48+400+32, 248), 280, 40, "", buttonFace)
+	settingsTestBtn := ui.NewButton(data.NewPoint[float32](48, 320), 120, 40, "Test", buttonFace)
+	settingsSaveBtn := ui.NewButton(data.NewPoint[float32](48+120+32, 320), 120, 40, "Save", buttonFace)
+	settingsBackBtn := ui.NewButton(data.NewPoint[float32](48+(120+32)*2, 320), 120, 40, "Back", buttonFace)
+	settingsStatusLabel := ui.NewLabel(data.NewPoint[float32](48, 392), "", buttonFace)
+
+	spectateInfoLabel := ui.NewLabel(data.NewPoint[float32](48, 440), "", buttonFace)
+	spectateLeaveBtn := ui.NewButton(data.NewPoint[float32](48, 570), 120, 40, "Leave", buttonFace)
+
+	connectionLabel := ui.NewLabel(data.NewPoint[float32](48, 680), "", buttonFace)
+
 	GlobalGameObjects.Acquire()
 	defer GlobalGameObjects.Release()
 
 	game := &Game{
-		debug: false,
+		debug: settings.Debug,
 
 		serverAddr: serverAddr,
 		serverPort: serverPort,
+		playerName: playerName,
+		settings:   settings,
+		window:     window,
+		difficulty: difficulty,
+		ruleSet:    ruleSet,
+		ruleSets:   ruleSetChoices(ruleSet),
 
 		events: make(chan events.GameEvent),
 
-		newGameBtn:  RegisterObject(newGameBtn),
-		joinGameBtn: RegisterObject(joinGameBtn),
-		exitBtn:     RegisterObject(exitBtn),
+		newGameBtn:      RegisterObject(newGameBtn),
+		privateGameBtn:  RegisterObject(privateGameBtn),
+		joinGameBtn:     RegisterObject(joinGameBtn),
+		watchGameBtn:    RegisterObject(watchGameBtn),
+		computerGameBtn: RegisterObject(computerGameBtn),
+		difficultyBtn:   RegisterObject(difficultyBtn),
+		ruleSetBtn:      RegisterObject(ruleSetBtn),
+		replaysBtn:      RegisterObject(replaysBtn),
+		settingsBtn:     RegisterObject(settingsBtn),
+		exitBtn:         RegisterObject(exitBtn),
 
 		newGameLoadingLabel: RegisterObject(newGameLoadingLabel),
+		newGameCodeLabel:    RegisterObject(newGameCodeLabel),
+
+		lobbyLabel:   RegisterObject(lobbyLabel),
+		lobbyList:    RegisterObject(lobbyList),
+		lobbyJoinBtn: RegisterObject(lobbyJoinBtn),
+		lobbyCodeBtn: RegisterObject(lobbyCodeBtn),
+		lobbyBackBtn: RegisterObject(lobbyBackBtn),
+
+		lobbyFleetsBtn: RegisterObject(lobbyFleetsBtn),
+
+		joinCodeTitleLabel:  RegisterObject(joinCodeTitleLabel),
+		joinCodeInput:       RegisterObject(joinCodeInput),
+		joinCodeStatusLabel: RegisterObject(joinCodeStatusLabel),
+		joinCodeBtn:         RegisterObject(joinCodeBtn),
+		joinCodeBackBtn:     RegisterObject(joinCodeBackBtn),
 
 		myBoard:    RegisterObject(myBoard),
 		myShipyard: RegisterObject(myShipyard),
@@ -151,18 +410,65 @@ func NewGame(serverAddr, serverPort string) (*Game, error) {
 		readyBtn:           RegisterObject(readyBtn),
 		notReadyBtn:        RegisterObject(notReadyBtn),
 		clearBoardBtn:      RegisterObject(clearBoardBtn),
+		autoBoardBtn:       RegisterObject(autoBoardBtn),
+		rng:                rand.New(rand.NewSource(time.Now().UnixNano())),
 		opponentReadyLabel: RegisterObject(opponentReadyLabel),
 
+		presetsLabel:      RegisterObject(presetsLabel),
+		presetsList:       RegisterObject(presetsList),
+		presetNameInput:   RegisterObject(presetNameInput),
+		presetSaveBtn:     RegisterObject(presetSaveBtn),
+		presetLoadBtn:     RegisterObject(presetLoadBtn),
+		presetDeleteBtn:   RegisterObject(presetDeleteBtn),
+		presetStatusLabel: RegisterObject(presetStatusLabel),
+
+		chatBox:   RegisterObject(chatBox),
+		chatInput: RegisterObject(chatInput),
+
 		opponentBoard:   RegisterObject(opponentBoard),
 		playerTurnLabel: RegisterObject(playerTurnLabel),
+		fireBtn:         RegisterObject(fireBtn),
 
 		theEndLabel: RegisterObject(theEndLabel),
 
+		replaysLabel:       RegisterObject(replaysLabel),
+		replaysList:        RegisterObject(replaysList),
+		replaysPlayBtn:     RegisterObject(replaysPlayBtn),
+		replaysBackBtn:     RegisterObject(replaysBackBtn),
+		replaysStatusLabel: RegisterObject(replaysStatusLabel),
+
+		replayLabel:     RegisterObject(replayLabel),
+		replayInfoLabel: RegisterObject(replayInfoLabel),
+		replayPrevBtn:   RegisterObject(replayPrevBtn),
+		replayPauseBtn:  RegisterObject(replayPauseBtn),
+		replayNextBtn:   RegisterObject(replayNextBtn),
+		replaySpeedBtn:  RegisterObject(replaySpeedBtn),
+		replayBackBtn:   RegisterObject(replayBackBtn),
+
+		settingsLabel:       RegisterObject(settingsLabel),
+		settingsAddrLabel:   RegisterObject(settingsAddrLabel),
+		settingsAddrInput:   RegisterObject(settingsAddrInput),
+		settingsPortLabel:   RegisterObject(settingsPortLabel),
+		settingsPortInput:   RegisterObject(settingsPortInput),
+		settingsNameLabel:   RegisterObject(settingsNameLabel),
+		settingsNameInput:   RegisterObject(settingsNameInput),
+		settingsThemeBtn:    RegisterObject(settingsThemeBtn),
+		settingsTestBtn:     RegisterObject(settingsTestBtn),
+		settingsSaveBtn:     RegisterObject(settingsSaveBtn),
+		settingsBackBtn:     RegisterObject(settingsBackBtn),
+		settingsStatusLabel: RegisterObject(settingsStatusLabel),
+
+		spectateInfoLabel: RegisterObject(spectateInfoLabel),
+		spectateLeaveBtn:  RegisterObject(spectateLeaveBtn),
+
+		connectionLabel: RegisterObject(connectionLabel),
+
 		objects: GlobalGameObjects.Objects(),
 	}
 
 	game.InitScenes()
 	game.currentScene = game.scenes[SceneMenu]
+	game.currentSceneID = SceneMenu
 	game.currentScene.OnEnter()
 
 	return game, nil
@@ -174,13 +480,19 @@ func (g *Game) Update() error {
 	}
 
 	if inpututil.IsKeyJustPressed(ebiten.KeyF10) {
-		ebiten.SetFullscreen(!ebiten.IsFullscreen())
+		g.settings.Fullscreen = !ebiten.IsFullscreen()
+		ebiten.SetFullscreen(g.settings.Fullscreen)
+		g.storeSettings()
 	}
 
 	if inpututil.IsKeyJustPressed(ebiten.KeyF3) {
 		g.debug = !g.debug
+		g.settings.Debug = g.debug
+		g.storeSettings()
 	}
 
+	g.updateWindowSettings()
+
 	cx, cy := ebiten.CursorPosition()
 	cp := data.NewPoint(float32(cx), float32(cy))
 
@@ -236,3 +548,37 @@ func (g *Game) LayoutF(logicalWindowWidth, logicalWindowHeight float64) (float64
 	scale := ebiten.DeviceScaleFactor()
 	return math.Ceil(logicalWindowWidth * scale), math.Ceil(logicalWindowHeight * scale)
 }
+
+// setupBoards prepares empty boards for the match played with rule set, chat of the previous match is cleared too
+func (g *Game) setupBoards(ruleSet rules.RuleSet) {
+	g.myBoard.Reset(ruleSet)
+	g.opponentBoard.Reset(ruleSet)
+	g.chatBox.Clear()
+	g.chatInput.Clear()
+}
+
+// ruleSetChoices returns rule sets that can be selected in menu, custom rule set is available only if it was
+// configured on start
+func ruleSetChoices(ruleSet rules.RuleSet) []rules.RuleSet {
+	choices := append([]rules.RuleSet(nil), rules.RuleSets...)
+	if _, ok := rules.RuleSetByName(ruleSet.Name); !ok {
+		choices = append(choices, ruleSet)
+	}
+
+	return choices
+}
+
+func ruleSetText(ruleSet rules.RuleSet) string {
+	return "Rules: " + ruleSet.Name
+}
+
+// nextRuleSet returns rule set that goes after the current one, after the last goes the first one
+func nextRuleSet(ruleSets []rules.RuleSet, ruleSet rules.RuleSet) rules.RuleSet {
+	for i, r := range ruleSets {
+		if r.Name == ruleSet.Name {
+			return ruleSets[(i+1)%len(ruleSets)]
+		}
+	}
+
+	return ruleSets[0]
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/mymmrac/battleship/client"
+	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server"
+	"github.com/mymmrac/battleship/server/api"
+)
+
+const lobbyRefreshInterval = 2 * time.Second
+
+const maxLobbyHostNameLen = 18
+
+// runLobby connects to the server and periodically refreshes list of open games until game to join is received or
+// lobby is stopped, in spectate mode running games are listed and selected game is watched instead
+func (g *Game) runLobby(join <-chan uuid.UUID, stop <-chan struct{}, spectate bool) {
+	sendEvent := func(event events.GameEvent) bool {
+		select {
+		case g.events <- event:
+			return true
+		case <-stop:
+			return false
+		}
+	}
+
+	var err error
+	g.grpcConn, err = grpc.Dial(serverTarget(g.serverAddr, g.serverPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		sendEvent(events.NewGameEventError(events.GameEventJoinGameFailed, err))
+		return
+	}
+
+	conn := g.grpcConn
+	joined := false
+	defer func() {
+		if !joined {
+			_ = conn.Close()
+		}
+	}()
+
+	g.eventManager, err = client.NewEventManagerClient(api.NewEventManagerClient(g.grpcConn))
+	if err != nil {
+		sendEvent(events.NewGameEventError(events.GameEventJoinGameFailed, err))
+		return
+	}
+
+	ticker := time.NewTicker(lobbyRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		var games []events.GameInfo
+		if spectate {
+			games, err = g.eventManager.ListRunningGames()
+		} else {
+			games, err = g.eventManager.ListGames()
+		}
+		if err != nil {
+			sendEvent(events.NewGameEventError(events.GameEventJoinGameFailed, err))
+			return
+		}
+
+		if !sendEvent(events.NewGameEventGameList(games)) {
+			return
+		}
+
+		select {
+		case gameID := <-join:
+			joined = true
+
+			if spectate {
+				g.spectateGame(gameID, sendEvent)
+				return
+			}
+
+			var ruleSet rules.RuleSet
+			ruleSet, err = g.eventManager.JoinGame(gameID)
+			if err != nil {
+				sendEvent(events.NewGameEventError(events.GameEventJoinGameFailed, err))
+				return
+			}
+
+			g.opponent = g.eventManager
+			if !sendEvent(events.NewGameEventJoined(ruleSet)) {
+				return
+			}
+
+			g.handleGameEvents()
+			return
+		case <-ticker.C:
+		// Refresh
+		case <-stop:
+			return
+		}
+	}
+}
+
+// spectateGame starts watching the game, snapshot of the game is sent as the first event
+func (g *Game) spectateGame(gameID uuid.UUID, sendEvent func(event events.GameEvent) bool) {
+	// Hide fleets option is set before game is selected
+	event, err := g.eventManager.Spectate(gameID, g.lobbyHideFleets)
+	if err != nil {
+		sendEvent(events.NewGameEventError(events.GameEventJoinGameFailed, err))
+		return
+	}
+
+	g.opponent = g.eventManager
+	if !sendEvent(events.NewServerEvent(event)) {
+		return
+	}
+
+	g.handleGameEvents()
+}
+
+func lobbyGameItem(info events.GameInfo) string {
+	hostName := []rune(info.HostName)
+	if len(hostName) == 0 {
+		hostName = []rune("Unknown")
+	} else if len(hostName) > maxLobbyHostNameLen {
+		hostName = append(hostName[:maxLobbyHostNameLen-1], '…')
+	}
+
+	return fmt.Sprintf("%-*s %-10s created %s",
+		maxLobbyHostNameLen, string(hostName), info.RuleSet, info.CreatedAt.Local().Format("15:04"))
+}
+
+// joinByCode connects to the server and joins private game
+func (g *Game) joinByCode(code string) {
+	conn, err := grpc.Dial(serverTarget(g.serverAddr, g.serverPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		g.events <- events.NewGameEventError(events.GameEventJoinGameFailed, err)
+		return
+	}
+
+	var ruleSet rules.RuleSet
+	eventManager, err := client.NewEventManagerClient(api.NewEventManagerClient(conn))
+	if err == nil {
+		ruleSet, err = eventManager.JoinGameByCode(code)
+	}
+	if err != nil {
+		_ = conn.Close()
+		g.events <- events.NewGameEventError(events.GameEventJoinGameFailed, err)
+		return
+	}
+
+	g.grpcConn = conn
+	g.eventManager = eventManager
+	g.opponent = eventManager
+	g.events <- events.NewGameEventJoined(ruleSet)
+
+	g.handleGameEvents()
+}
+
+// joinCodeFilter accepts join code characters typed in any case
+func joinCodeFilter(r rune) (rune, bool) {
+	r = unicode.ToUpper(r)
+	return r, server.IsJoinCodeRune(r)
+}
@@ -3,25 +3,64 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/user"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/spf13/cobra"
 
+	"github.com/mymmrac/battleship/ai"
 	"github.com/mymmrac/battleship/cmd"
+	"github.com/mymmrac/battleship/cmd/bot"
 	"github.com/mymmrac/battleship/cmd/server"
+	"github.com/mymmrac/battleship/cmd/simulate"
+	"github.com/mymmrac/battleship/rules"
 )
 
 func main() {
 	var serverAddr string
 	var serverPort string
+	var playerName string
+	var difficultyName string
 
 	rootCmd := &cobra.Command{
 		Use:   "battleship",
 		Short: "Battleship is two players sea battle game",
-		Run: func(cmd *cobra.Command, args []string) {
+		Run: func(command *cobra.Command, args []string) {
 			fmt.Println("Starting...")
 
-			game, err := NewGame(serverAddr, serverPort)
+			// Stored settings are used for everything that is not set explicitly
+			if !command.Flags().Changed("address") {
+				serverAddr = ""
+			}
+			if !command.Flags().Changed("port") {
+				serverPort = ""
+			}
+			if !command.Flags().Changed("name") {
+				playerName = ""
+			}
+
+			var difficulty ai.Difficulty
+			if command.Flags().Changed("difficulty") {
+				var err error
+				difficulty, err = ai.ParseDifficulty(difficultyName)
+				if err != nil {
+					_, _ = fmt.Fprintf(os.Stderr, "Bad difficulty: %s\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var ruleSet rules.RuleSet
+			if cmd.RuleSetFlagsChanged(command) {
+				var err error
+				ruleSet, err = cmd.RuleSetFromFlags(command)
+				if err != nil {
+					_, _ = fmt.Fprintf(os.Stderr, "Bad rule set: %s\n", err)
+					os.Exit(1)
+				}
+			}
+
+			game, err := NewGame(serverAddr, serverPort, playerName, difficulty, ruleSet)
 			if err != nil {
 				_, _ = fmt.Fprintf(os.Stderr, "Load game failed: %s\n", err)
 				os.Exit(1)
@@ -36,8 +75,15 @@ func main() {
 		},
 	}
 
-	rootCmd.Flags().StringVarP(&serverAddr, "address", "a", "127.0.0.1", "Battleship server address used to connect")
-	rootCmd.Flags().StringVarP(&serverPort, "port", "p", server.DefaultGRPCPort, "Battleship server port used to connect")
+	rootCmd.Flags().StringVarP(&serverAddr, "address", "a", defaultServerAddr,
+		"Battleship server address used to connect, overrides address from settings")
+	rootCmd.Flags().StringVarP(&serverPort, "port", "p", server.DefaultGRPCPort,
+		"Battleship server port used to connect, overrides port from settings")
+	rootCmd.Flags().StringVarP(&playerName, "name", "n", defaultPlayerName(),
+		"Player name shown to other players, overrides name from settings")
+	rootCmd.Flags().StringVarP(&difficultyName, "difficulty", "d", ai.DefaultDifficulty.String(),
+		"Computer opponent difficulty, overrides difficulty from settings: "+strings.Join(ai.DifficultyNames(), ", "))
+	cmd.RuleSetFlags(rootCmd)
 
 	serverCmd := &cobra.Command{
 		Use:   "server",
@@ -47,7 +93,47 @@ func main() {
 
 	server.BattleshipServerFlags(serverCmd)
 
-	rootCmd.AddCommand(serverCmd)
+	botCmd := &cobra.Command{
+		Use:   "bot",
+		Short: "Headless bot that plays on battleship server",
+		RunE:  bot.BattleshipBotRunE,
+	}
+
+	bot.BattleshipBotFlags(botCmd)
+
+	simulateCmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Play games between computer strategies and show statistics",
+		RunE:  simulate.BattleshipSimulateRunE,
+	}
+
+	simulate.BattleshipSimulateFlags(simulateCmd)
+
+	replayCmd := &cobra.Command{
+		Use:   "replay <file>",
+		Short: "Play back recorded match",
+		Args:  cobra.ExactArgs(1),
+		Run: func(command *cobra.Command, args []string) {
+			game, err := NewGame("", "", "", 0, rules.RuleSet{})
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Load game failed: %s\n", err)
+				os.Exit(1)
+			}
+
+			game.replayOnly = true
+			if err = game.startPlayback(args[0]); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Load replay failed: %s\n", err)
+				os.Exit(1)
+			}
+
+			if err = ebiten.RunGame(game); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Game crashed: %s\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	rootCmd.AddCommand(serverCmd, botCmd, simulateCmd, replayCmd)
 
 	cmd.WalkCmd(rootCmd, cmd.UpdateHelp)
 
@@ -55,3 +141,12 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func defaultPlayerName() string {
+	currentUser, err := user.Current()
+	if err != nil || currentUser.Username == "" {
+		return "Player"
+	}
+
+	return currentUser.Username
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server/api"
+)
+
+// handleGameEvents forwards server events to the game until connection is lost and can't be resumed
+func (g *Game) handleGameEvents() {
+	err := g.opponent.HandleGameEvents(g.events)
+	if errors.Is(err, errGameEnded) {
+		return
+	}
+
+	g.events <- events.NewGameEventError(events.GameEventDisconnected, err)
+}
+
+// handleConnectionEvent handles connection loss and resume of the game, returns true if event was handled
+func (g *Game) handleConnectionEvent(event events.GameEvent) bool {
+	switch event.EventType() {
+	case events.GameEventConnectionLost:
+		g.connectionLabel.SetText("Connection lost, reconnecting...")
+		g.connectionLabel.Show()
+		return true
+	case events.GameEventDisconnected:
+		fmt.Println(event.(events.GameEventError).Err) // TODO: Fix me
+		g.connectionLabel.Hide()
+		g.disconnected = true
+		g.ChangeScene(SceneTheEnd)
+		return true
+	case events.GameEventFromServer:
+		snapshot := event.(events.ServerEvent).Event.GetSnapshot()
+		if snapshot == nil {
+			return false
+		}
+
+		g.connectionLabel.Hide()
+		g.applySnapshot(snapshot)
+		return true
+	default:
+		return false
+	}
+}
+
+// applySnapshot restores game state received after reconnect and switches to the matching scene
+func (g *Game) applySnapshot(snapshot *api.Snapshot) {
+	var sceneID SceneID
+	switch snapshot.GetState() {
+	case api.GameState_GAME_STATE_WAITING:
+		sceneID = SceneNewGame
+	case api.GameState_GAME_STATE_PLACING:
+		if snapshot.GetReady() {
+			sceneID = ScenePlayerReady
+		} else {
+			sceneID = ScenePlaceShips
+		}
+	case api.GameState_GAME_STATE_PLAYING:
+		sceneID = SceneTheGame
+	case api.GameState_GAME_STATE_FINISHED:
+		sceneID = SceneTheEnd
+	default:
+		fmt.Println("unknown game state:", snapshot.GetState()) // TODO: Fix me
+		return
+	}
+
+	// Ships placed while not ready are not known to server, so they are kept
+	if snapshot.GetReady() {
+		if err := events.BoardFromGRPC(snapshot.GetBoard(), g.myBoard.model); err != nil {
+			fmt.Println(err) // TODO: Fix me
+			return
+		}
+	}
+
+	if err := events.BoardFromGRPC(snapshot.GetOpponentBoard(), g.opponentBoard.model); err != nil {
+		fmt.Println(err) // TODO: Fix me
+		return
+	}
+
+	g.setOpponentReady(snapshot.GetOpponentReady())
+	g.shots = int(snapshot.GetShots())
+	g.shotSent = false
+	g.setMyTurn(snapshot.GetMyTurn())
+
+	g.won = snapshot.GetWon()
+
+	if g.currentSceneID != sceneID {
+		g.ChangeScene(sceneID)
+	}
+}
+
+func (g *Game) setOpponentReady(ready bool) {
+	g.opponentReady = ready
+	if ready {
+		g.opponentReadyLabel.SetText("Opponent: ready")
+	} else {
+		g.opponentReadyLabel.SetText("Opponent: not ready")
+	}
+}
+
+func (g *Game) setMyTurn(myTurn bool) {
+	g.myTurn = myTurn
+	switch {
+	case myTurn && g.salvo():
+		g.playerTurnLabel.SetText(fmt.Sprintf("Your Turn: %d shots", g.shots))
+	case myTurn:
+		g.playerTurnLabel.SetText("Your Turn")
+	default:
+		g.playerTurnLabel.SetText("Opponent's Turn")
+	}
+}
+
+// salvo reports whether current match is played in salvo mode
+func (g *Game) salvo() bool {
+	return g.opponentBoard.model.RuleSet().Salvo != rules.SalvoOff
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/events"
+)
+
+// Opponent is the other side of the match, either a remote player connected through server or a local computer
+type Opponent interface {
+	Ready(ships [][]data.Point[int]) error
+	NotReady() error
+	Shoot(pos data.Point[int]) error
+	Salvo(targets []data.Point[int]) error
+	HandleGameEvents(gameEvents chan<- events.GameEvent) error
+}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/atotto/clipboard"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
+)
+
+const (
+	appConfigDirName = "battleship"
+	presetsDirName   = "presets"
+	presetExt        = ".json"
+
+	maxPresetNameLen = 24
+)
+
+// FleetPreset is a saved layout of ships, it can be loaded to any board of the same size
+type FleetPreset struct {
+	Name   string              `json:"-"`
+	Width  int                 `json:"width"`
+	Height int                 `json:"height"`
+	Ships  [][]data.Point[int] `json:"ships"`
+}
+
+// appConfigDir returns directory where game stores its files, it's created if missing
+func appConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir = filepath.Join(dir, appConfigDirName)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func presetsDir() (string, error) {
+	dir, err := appConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir = filepath.Join(dir, presetsDirName)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// isPresetNameRune reports whether rune can be used in preset name, names are also used as file names
+func isPresetNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' || r == '-' || r == '_'
+}
+
+func presetNameFilter(r rune) (rune, bool) {
+	return r, isPresetNameRune(r)
+}
+
+func validPresetName(name string) bool {
+	if strings.TrimSpace(name) == "" || len([]rune(name)) > maxPresetNameLen {
+		return false
+	}
+
+	for _, r := range name {
+		if !isPresetNameRune(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// listPresets returns all saved presets sorted by name
+func listPresets() ([]FleetPreset, error) {
+	dir, err := presetsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var presets []FleetPreset
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), presetExt)
+		if entry.IsDir() || !ok || !validPresetName(name) {
+			continue
+		}
+
+		var preset FleetPreset
+		preset, err = loadPreset(name)
+		if err != nil {
+			// Broken presets are skipped, so that they don't hide others
+			continue
+		}
+
+		presets = append(presets, preset)
+	}
+
+	sort.Slice(presets, func(i, j int) bool {
+		return strings.ToLower(presets[i].Name) < strings.ToLower(presets[j].Name)
+	})
+
+	return presets, nil
+}
+
+func loadPreset(name string) (FleetPreset, error) {
+	if !validPresetName(name) {
+		return FleetPreset{}, fmt.Errorf("bad preset name: %q", name)
+	}
+
+	dir, err := presetsDir()
+	if err != nil {
+		return FleetPreset{}, err
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, name+presetExt))
+	if err != nil {
+		return FleetPreset{}, err
+	}
+
+	var preset FleetPreset
+	if err = json.Unmarshal(content, &preset); err != nil {
+		return FleetPreset{}, fmt.Errorf("preset %q: %w", name, err)
+	}
+	preset.Name = name
+
+	return preset, nil
+}
+
+// savePreset saves ships of the board as preset, preset with the same name is replaced
+func savePreset(name string, board *rules.Board) error {
+	if !validPresetName(name) {
+		return fmt.Errorf("bad preset name: %q", name)
+	}
+
+	ships, err := board.FleetShips()
+	if err != nil {
+		return err
+	}
+	if len(ships) == 0 {
+		return errors.New("no ships to save")
+	}
+
+	dir, err := presetsDir()
+	if err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(FleetPreset{
+		Width:  board.Width(),
+		Height: board.Height(),
+		Ships:  ships,
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, name+presetExt), content, 0o644)
+}
+
+func deletePreset(name string) error {
+	if !validPresetName(name) {
+		return fmt.Errorf("bad preset name: %q", name)
+	}
+
+	dir, err := presetsDir()
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(filepath.Join(dir, name+presetExt))
+}
+
+// Apply places preset ships on the board, ships are kept even if they don't match board's fleet, so they can be fixed
+func (p FleetPreset) Apply(board *rules.Board) error {
+	if p.Width != board.Width() || p.Height != board.Height() {
+		return fmt.Errorf("preset is for %dx%d board", p.Width, p.Height)
+	}
+
+	return board.PlaceFleet(p.Ships)
+}
+
+func (p FleetPreset) String() string {
+	return fmt.Sprintf("%s (%dx%d)", p.Name, p.Width, p.Height)
+}
+
+// refreshPresets reloads list of saved presets, selection is kept on preset with the same name
+func (g *Game) refreshPresets() {
+	selectedName := ""
+	if selected := g.presetsList.Selected(); selected >= 0 && selected < len(g.presets) {
+		selectedName = g.presets[selected].Name
+	}
+
+	presets, err := listPresets()
+	if err != nil {
+		g.presetStatusLabel.SetText("Failed to list presets: " + err.Error())
+	}
+	g.presets = presets
+
+	items := make([]string, 0, len(presets))
+	selected := -1
+	for i, preset := range presets {
+		items = append(items, preset.String())
+		if preset.Name == selectedName {
+			selected = i
+		}
+	}
+
+	g.presetsList.SetItems(items)
+	g.presetsList.SetSelected(selected)
+}
+
+// updatePresets handles saving, loading or deleting of presets, preset is also saved when its name is submitted
+func (g *Game) updatePresets() {
+	// List is controlled by keyboard too, so it's disabled while text is typed
+	if g.typing() {
+		g.presetsList.Disable()
+	} else {
+		g.presetsList.Enable()
+	}
+
+	name := g.presetNameInput.Text()
+	canSave := validPresetName(name) && len(g.myBoard.model.Ships()) > 0
+
+	selected := g.presetsList.Selected()
+	g.presetSaveBtn.SetActive(canSave)
+	g.presetLoadBtn.SetActive(selected >= 0)
+	g.presetDeleteBtn.SetActive(selected >= 0)
+
+	switch {
+	case g.presetSaveBtn.Clicked() || canSave && g.presetNameInput.Submitted():
+		if err := savePreset(name, g.myBoard.model); err != nil {
+			g.presetStatusLabel.SetText("Failed to save: " + err.Error())
+			return
+		}
+
+		g.presetStatusLabel.SetText("Saved " + name)
+		g.refreshPresets()
+	case g.presetLoadBtn.Clicked() || g.presetsList.Activated():
+		if selected < 0 {
+			return
+		}
+
+		preset := g.presets[selected]
+		g.myBoard.cancelDrag()
+		if err := preset.Apply(g.myBoard.model); err != nil {
+			g.presetStatusLabel.SetText("Preset doesn't match rules: " + err.Error())
+			return
+		}
+
+		g.presetNameInput.SetText(preset.Name)
+		g.presetStatusLabel.SetText("Loaded " + preset.Name)
+	case g.presetDeleteBtn.Clicked():
+		if selected < 0 {
+			return
+		}
+
+		name := g.presets[selected].Name
+		if err := deletePreset(name); err != nil {
+			g.presetStatusLabel.SetText("Failed to delete: " + err.Error())
+			return
+		}
+
+		g.presetStatusLabel.SetText("Deleted " + name)
+		g.refreshPresets()
+	}
+}
+
+// typing reports whether any text input on placement screen is focused, keyboard shortcuts are ignored while typing
+func (g *Game) typing() bool {
+	return g.presetNameInput.Focused() || g.chatInput.Focused()
+}
+
+// updateClipboard copies board to clipboard on Ctrl+C and places fleet pasted from clipboard on Ctrl+V, both in
+// board notation followed by ships in fleet notation
+func (g *Game) updateClipboard() {
+	if g.typing() || !ebiten.IsKeyPressed(ebiten.KeyControl) && !ebiten.IsKeyPressed(ebiten.KeyMeta) {
+		return
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyC):
+		if err := clipboard.WriteAll(fleetNotation(g.myBoard.model)); err != nil {
+			g.presetStatusLabel.SetText("Failed to copy: " + err.Error())
+			return
+		}
+
+		g.presetStatusLabel.SetText("Copied board")
+	case inpututil.IsKeyJustPressed(ebiten.KeyV):
+		text, err := clipboard.ReadAll()
+		if err != nil {
+			g.presetStatusLabel.SetText("Failed to paste: " + err.Error())
+			return
+		}
+
+		ships, err := parseFleetNotation(g.myBoard.model.RuleSet(), text)
+		if err != nil {
+			g.presetStatusLabel.SetText("Failed to paste: " + err.Error())
+			return
+		}
+
+		g.myBoard.cancelDrag()
+		if err = g.myBoard.model.PlaceFleet(ships); err != nil {
+			g.presetStatusLabel.SetText("Pasted fleet doesn't match rules: " + err.Error())
+			return
+		}
+
+		g.presetStatusLabel.SetText("Pasted fleet")
+	}
+}
+
+// fleetNotation returns board in board notation followed by the line with its ships in fleet notation, ships that may
+// touch can be told apart only by the latter
+func fleetNotation(board *rules.Board) string {
+	text := board.String()
+	if ships, err := board.FleetShips(); err == nil && len(ships) > 0 {
+		text += rules.FormatShips(ships) + "\n"
+	}
+
+	return text
+}
+
+// parseFleetNotation parses ships from the last line in fleet notation, if there is no such line, ships are found on
+// the board in board notation
+func parseFleetNotation(ruleSet rules.RuleSet, text string) ([][]data.Point[int], error) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if ships, err := rules.ParseShips(ruleSet, lines[len(lines)-1]); err == nil {
+		return ships, nil
+	}
+
+	board, err := rules.ParseBoard(ruleSet, text)
+	if err != nil {
+		return nil, err
+	}
+
+	return board.FleetShips()
+}
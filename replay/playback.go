@@ -0,0 +1,121 @@
+package replay
+
+import (
+	"time"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
+)
+
+// MaxShotDelay limits pause between shots during playback, so that long thinking doesn't stall it
+const MaxShotDelay = 2 * time.Second
+
+// Playback steps through replay shots, boards always show state after the first Position shots
+type Playback struct {
+	replay   *Replay
+	position int
+
+	board         *rules.Board
+	opponentBoard *rules.Board
+}
+
+func NewPlayback(replay *Replay) *Playback {
+	playback := &Playback{
+		replay:        replay,
+		board:         rules.NewBoard(replay.RuleSet),
+		opponentBoard: rules.NewBoard(replay.RuleSet),
+	}
+	playback.Seek(0)
+
+	return playback
+}
+
+func (p *Playback) Replay() *Replay {
+	return p.replay
+}
+
+// Boards returns player's and opponent's boards, they are updated in place on seek
+func (p *Playback) Boards() (*rules.Board, *rules.Board) {
+	return p.board, p.opponentBoard
+}
+
+func (p *Playback) Position() int {
+	return p.position
+}
+
+func (p *Playback) Len() int {
+	return len(p.replay.Shots)
+}
+
+func (p *Playback) Finished() bool {
+	return p.position >= len(p.replay.Shots)
+}
+
+// Step moves forward or back by number of shots
+func (p *Playback) Step(shots int) {
+	p.Seek(p.position + shots)
+}
+
+// Seek rebuilds boards from fleets applying first shots, position is clamped to the replay length
+func (p *Playback) Seek(position int) {
+	if position < 0 {
+		position = 0
+	}
+	if position > len(p.replay.Shots) {
+		position = len(p.replay.Shots)
+	}
+
+	// Moving forward only applies new shots
+	if position < p.position || position == 0 {
+		placeFleet(p.board, p.replay.Fleet)
+		placeFleet(p.opponentBoard, p.replay.OpponentFleet)
+		p.position = 0
+	}
+
+	for ; p.position < position; p.position++ {
+		shot := p.replay.Shots[p.position]
+
+		board := p.opponentBoard
+		if shot.ByOpponent {
+			board = p.board
+		}
+
+		if board.InBounds(shot.Pos) {
+			board.Mark(shot.Pos, shot.Result)
+		}
+	}
+}
+
+// NextDelay returns time between the last applied shot and the next one, as it was in the match
+func (p *Playback) NextDelay() time.Duration {
+	if p.Finished() {
+		return 0
+	}
+
+	next := p.replay.Shots[p.position].Time
+	previous := p.replay.StartedAt
+	if p.position > 0 {
+		previous = p.replay.Shots[p.position-1].Time
+	}
+
+	delay := next.Sub(previous)
+	if delay > MaxShotDelay {
+		return MaxShotDelay
+	}
+	if delay < 0 {
+		return 0
+	}
+
+	return delay
+}
+
+// placeFleet places fleet cells on the board as is without validation, recorded shot results don't need ships to be
+// known
+func placeFleet(board *rules.Board, fleet []data.Point[int]) {
+	board.Clear()
+	for _, pos := range fleet {
+		if board.InBounds(pos) {
+			board.Set(pos, rules.CellShip)
+		}
+	}
+}
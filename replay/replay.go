@@ -0,0 +1,171 @@
+// Package replay records matches and plays them back
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/rules"
+)
+
+const (
+	fileExt        = ".json"
+	fileTimeFormat = "2006-01-02_15-04-05"
+)
+
+type Outcome string
+
+const (
+	OutcomeUnknown      Outcome = ""
+	OutcomeWon          Outcome = "won"
+	OutcomeLost         Outcome = "lost"
+	OutcomeOpponentLeft Outcome = "opponent left"
+	OutcomeDisconnected Outcome = "disconnected"
+)
+
+// Replay is an ordered log of the match from player's point of view
+type Replay struct {
+	RuleSet   rules.RuleSet `json:"rule_set"`
+	Player    string        `json:"player"`
+	Opponent  string        `json:"opponent"`
+	StartedAt time.Time     `json:"started_at"`
+	Outcome   Outcome       `json:"outcome"`
+
+	Fleet []data.Point[int] `json:"fleet"`
+	// OpponentFleet is revealed only when match ends, if opponent left only hit cells are known
+	OpponentFleet []data.Point[int] `json:"opponent_fleet"`
+
+	Shots []Shot `json:"shots"`
+}
+
+// Shot is a single shot of player or opponent, shots fired as salvo share the same time
+type Shot struct {
+	Time       time.Time        `json:"time"`
+	Pos        data.Point[int]  `json:"pos"`
+	Result     rules.ShotResult `json:"result"`
+	ByOpponent bool             `json:"by_opponent"`
+}
+
+// New starts recording of the match with player's fleet
+func New(ruleSet rules.RuleSet, player, opponent string, fleet []data.Point[int]) *Replay {
+	return &Replay{
+		RuleSet:   ruleSet,
+		Player:    player,
+		Opponent:  opponent,
+		StartedAt: time.Now(),
+		Fleet:     fleet,
+	}
+}
+
+func (r *Replay) AddShot(pos data.Point[int], result rules.ShotResult, byOpponent bool) {
+	r.Shots = append(r.Shots, Shot{
+		Time:       time.Now(),
+		Pos:        pos,
+		Result:     result,
+		ByOpponent: byOpponent,
+	})
+}
+
+// Finish records outcome of the match and opponent's fleet if it was revealed
+func (r *Replay) Finish(outcome Outcome, opponentFleet []data.Point[int]) {
+	r.Outcome = outcome
+
+	if opponentFleet != nil {
+		r.OpponentFleet = opponentFleet
+		return
+	}
+
+	// Opponent's ships that were hit are known anyway
+	for _, shot := range r.Shots {
+		if !shot.ByOpponent && shot.Result != rules.ShotMiss {
+			r.OpponentFleet = append(r.OpponentFleet, shot.Pos)
+		}
+	}
+}
+
+func (r *Replay) String() string {
+	outcome := string(r.Outcome)
+	if outcome == "" {
+		outcome = "unfinished"
+	}
+
+	return fmt.Sprintf("%s %s vs %s, %s",
+		r.StartedAt.Format("2006-01-02 15:04"), r.RuleSet.Name, r.Opponent, outcome)
+}
+
+// Save writes replay into directory, file is named by match start time
+func Save(dir string, replay *Replay) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(replay)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, replay.StartedAt.Format(fileTimeFormat)+fileExt)
+	if err = os.WriteFile(path, content, 0o644); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+func Load(path string) (*Replay, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var replay Replay
+	if err = json.Unmarshal(content, &replay); err != nil {
+		return nil, fmt.Errorf("replay %s: %w", filepath.Base(path), err)
+	}
+
+	if err = replay.RuleSet.Validate(); err != nil {
+		return nil, fmt.Errorf("replay %s: %w", filepath.Base(path), err)
+	}
+
+	// Files may be edited or written by other versions, so unknown results are rejected before playback
+	for i, shot := range replay.Shots {
+		switch shot.Result {
+		case rules.ShotMiss, rules.ShotHit, rules.ShotDestroyed:
+			// Valid
+		default:
+			return nil, fmt.Errorf("replay %s: unknown result of shot %d: %d", filepath.Base(path), i+1, shot.Result)
+		}
+	}
+
+	return &replay, nil
+}
+
+// List returns paths of replays in directory, the newest first, missing directory has no replays
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), fileExt) {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	// File names start with time, so they are sorted by it
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+
+	return paths, nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/replay"
+	"github.com/mymmrac/battleship/rules"
+)
+
+const replaysDirName = "replays"
+
+// replaySpeeds are playback speeds that can be selected, the second one is a real time
+var replaySpeeds = []float64{0.5, 1, 2, 4, 8}
+
+func replaysDir() (string, error) {
+	dir, err := appConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, replaysDirName), nil
+}
+
+// startRecording starts recording of the match that just started
+func (g *Game) startRecording() {
+	g.recording = replay.New(g.myBoard.model.RuleSet(), g.playerName, g.opponentName, g.myBoard.model.Ships())
+}
+
+func (g *Game) recordShot(pos data.Point[int], result rules.ShotResult, byOpponent bool) {
+	if g.recording == nil {
+		return
+	}
+
+	g.recording.AddShot(pos, result, byOpponent)
+}
+
+// finishRecording saves recorded match, opponent's fleet is nil if it wasn't revealed
+func (g *Game) finishRecording(outcome replay.Outcome, opponentFleet []data.Point[int]) {
+	if g.recording == nil {
+		return
+	}
+
+	recording := g.recording
+	g.recording = nil
+	recording.Finish(outcome, opponentFleet)
+
+	dir, err := replaysDir()
+	if err != nil {
+		fmt.Println(err) // TODO: Fix me
+		return
+	}
+
+	if _, err = replay.Save(dir, recording); err != nil {
+		fmt.Println(err) // TODO: Fix me
+	}
+}
+
+// refreshReplays reloads list of saved replays
+func (g *Game) refreshReplays() {
+	g.replayPaths = nil
+	g.replaysStatusLabel.SetText("")
+
+	dir, err := replaysDir()
+	if err != nil {
+		g.replaysStatusLabel.SetText("Failed to list replays: " + err.Error())
+		return
+	}
+
+	paths, err := replay.List(dir)
+	if err != nil {
+		g.replaysStatusLabel.SetText("Failed to list replays: " + err.Error())
+		return
+	}
+
+	items := make([]string, 0, len(paths))
+	for _, path := range paths {
+		// Broken replays are skipped, so that they don't hide others
+		loaded, loadErr := replay.Load(path)
+		if loadErr != nil {
+			continue
+		}
+
+		g.replayPaths = append(g.replayPaths, path)
+		items = append(items, loaded.String())
+	}
+
+	g.replaysList.SetItems(items)
+}
+
+// startPlayback shows replay from the file in the replay scene
+func (g *Game) startPlayback(path string) error {
+	loaded, err := replay.Load(path)
+	if err != nil {
+		return err
+	}
+
+	g.setupBoards(loaded.RuleSet)
+	g.playback = replay.NewPlayback(loaded)
+	g.myBoard.model, g.opponentBoard.model = g.playback.Boards()
+
+	g.replayPaused = false
+	g.replaySpeedIndex = 1
+	g.replayElapsed = 0
+
+	g.ChangeScene(SceneReplay)
+	return nil
+}
+
+// updatePlayback handles playback controls and plays shots in the same pace as they were made
+func (g *Game) updatePlayback() {
+	if g.replayPauseBtn.Clicked() || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		g.replayPaused = !g.replayPaused
+	}
+
+	step := 0
+	if g.replayPrevBtn.Clicked() || inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+		step = -1
+	}
+	if g.replayNextBtn.Clicked() || inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
+		step = 1
+	}
+	if step != 0 {
+		g.replayPaused = true
+		g.playback.Step(step)
+		g.replayElapsed = 0
+	}
+
+	if g.replaySpeedBtn.Clicked() || inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
+		g.replaySpeedIndex = (g.replaySpeedIndex + 1) % len(replaySpeeds)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) {
+		g.replaySpeedIndex = (g.replaySpeedIndex + len(replaySpeeds) - 1) % len(replaySpeeds)
+	}
+
+	if !g.replayPaused && !g.playback.Finished() {
+		tick := time.Second / time.Duration(ebiten.TPS())
+		g.replayElapsed += time.Duration(float64(tick) * replaySpeeds[g.replaySpeedIndex])
+		if g.replayElapsed >= g.playback.NextDelay() {
+			g.replayElapsed = 0
+			g.playback.Step(1)
+		}
+	}
+
+	g.updatePlaybackLabels()
+}
+
+func (g *Game) updatePlaybackLabels() {
+	played := g.playback.Replay()
+
+	status := fmt.Sprintf("Shot %d/%d", g.playback.Position(), g.playback.Len())
+	if g.playback.Finished() && played.Outcome != replay.OutcomeUnknown {
+		status += ", " + string(played.Outcome)
+	}
+	g.replayLabel.SetText(status)
+	g.replayInfoLabel.SetText(played.Player + " vs " + played.Opponent)
+
+	if g.replayPaused {
+		g.replayPauseBtn.SetText("Play")
+	} else {
+		g.replayPauseBtn.SetText("Pause")
+	}
+	g.replaySpeedBtn.SetText("x" + strconv.FormatFloat(replaySpeeds[g.replaySpeedIndex], 'f', -1, 64))
+}
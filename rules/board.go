@@ -0,0 +1,677 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/mymmrac/battleship/data"
+)
+
+// neighbours lists directions to cells around a cell, side neighbours first
+var neighbours = []data.Point[int]{
+	{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1},
+	{X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: 1}, {X: -1, Y: -1},
+}
+
+type Board struct {
+	ruleSet RuleSet
+	cells   [][]Cell
+	// ships contains cells of each ship when they are known: placed ship by ship or the whole fleet at once
+	ships [][]data.Point[int]
+}
+
+func NewBoard(ruleSet RuleSet) *Board {
+	return &Board{
+		ruleSet: ruleSet,
+		cells:   newGrid[Cell](ruleSet.Width, ruleSet.Height),
+	}
+}
+
+func (b *Board) RuleSet() RuleSet {
+	return b.ruleSet
+}
+
+func (b *Board) Fleet() Fleet {
+	return b.ruleSet.Fleet
+}
+
+func (b *Board) Width() int {
+	return b.ruleSet.Width
+}
+
+func (b *Board) Height() int {
+	return b.ruleSet.Height
+}
+
+func (b *Board) InBounds(pos data.Point[int]) bool {
+	return b.inBounds(pos.X, pos.Y)
+}
+
+func (b *Board) At(pos data.Point[int]) Cell {
+	return b.cells[pos.Y][pos.X]
+}
+
+func (b *Board) Set(pos data.Point[int], cell Cell) {
+	b.cells[pos.Y][pos.X] = cell
+}
+
+func (b *Board) Clear() {
+	b.cells = newGrid[Cell](b.ruleSet.Width, b.ruleSet.Height)
+	b.ships = nil
+}
+
+// CanPlace reports whether ship cell can be placed at position without breaking placement rules, fleet may still be
+// incomplete after that
+func (b *Board) CanPlace(pos data.Point[int]) bool {
+	x, y := pos.X, pos.Y
+
+	if !b.inBounds(x, y) || b.cells[y][x] != CellEmpty {
+		return false
+	}
+
+	switch b.ruleSet.Touching {
+	case TouchingNone:
+		// Check diagonals
+		if b.isShip(x-1, y-1) || b.isShip(x+1, y-1) || b.isShip(x-1, y+1) || b.isShip(x+1, y+1) {
+			return false
+		}
+	case TouchingFree:
+		// Ships that may touch are told apart only when placed ship by ship, so only number of cells is limited
+		return len(b.Ships()) < b.ruleSet.Fleet.Cells()
+	}
+
+	// Cells connected by sides belong to the same ship, so it must stay straight and not too long
+	b.cells[y][x] = CellShip
+	ship := b.connectedShip(x, y)
+	b.cells[y][x] = CellEmpty
+
+	return isStraight(ship) && len(ship) <= b.ruleSet.Fleet.LongestShip()
+}
+
+func (b *Board) Place(pos data.Point[int]) bool {
+	if !b.CanPlace(pos) {
+		return false
+	}
+
+	b.cells[pos.Y][pos.X] = CellShip
+	b.ships = nil
+	return true
+}
+
+func (b *Board) Remove(pos data.Point[int]) bool {
+	if !b.isShip(pos.X, pos.Y) {
+		return false
+	}
+
+	b.cells[pos.Y][pos.X] = CellEmpty
+	b.ships = nil
+	return true
+}
+
+// ShipAt returns cells of ship at position or nil if there is no ship
+func (b *Board) ShipAt(pos data.Point[int]) []data.Point[int] {
+	if !b.isShip(pos.X, pos.Y) {
+		return nil
+	}
+
+	return b.shipAt(pos)
+}
+
+// CanPlaceShip reports whether straight ship fits into board and doesn't touch other ships in a way forbidden by the
+// rule set
+func (b *Board) CanPlaceShip(ship []data.Point[int]) bool {
+	if len(ship) == 0 || !isStraight(ship) {
+		return false
+	}
+
+	first, last := ship[0], ship[0]
+	for _, pos := range ship {
+		if !b.inBounds(pos.X, pos.Y) || b.cells[pos.Y][pos.X] != CellEmpty {
+			return false
+		}
+
+		if pos.X < first.X || pos.Y < first.Y {
+			first = pos
+		}
+		if pos.X > last.X || pos.Y > last.Y {
+			last = pos
+		}
+	}
+
+	return b.canPlaceShip(first.X, first.Y, last.X, last.Y)
+}
+
+// PlaceShip places the whole ship, ships stay known if all ships on the board were placed this way
+func (b *Board) PlaceShip(ship []data.Point[int]) bool {
+	if !b.CanPlaceShip(ship) {
+		return false
+	}
+
+	known := b.ships != nil || len(b.Ships()) == 0
+	for _, pos := range ship {
+		b.cells[pos.Y][pos.X] = CellShip
+	}
+
+	if known {
+		b.ships = append(b.ships, ship)
+	} else {
+		b.ships = nil
+	}
+	return true
+}
+
+// RemoveShip removes ship cells from the board
+func (b *Board) RemoveShip(ship []data.Point[int]) {
+	for _, pos := range ship {
+		if b.isShip(pos.X, pos.Y) {
+			b.cells[pos.Y][pos.X] = CellEmpty
+		}
+	}
+
+	for i, known := range b.ships {
+		if len(ship) > 0 && len(known) > 0 && known[0] == ship[0] {
+			b.ships = append(b.ships[:i], b.ships[i+1:]...)
+			return
+		}
+	}
+}
+
+// ShipCells returns cells of straight ship that starts at position and goes right or down
+func ShipCells(start data.Point[int], length int, vertical bool) []data.Point[int] {
+	dir := data.NewPoint(1, 0)
+	if vertical {
+		dir = data.NewPoint(0, 1)
+	}
+
+	ship := make([]data.Point[int], 0, length)
+	for i := 0; i < length; i++ {
+		ship = append(ship, data.NewPoint(start.X+dir.X*i, start.Y+dir.Y*i))
+	}
+
+	return ship
+}
+
+// PlaceFleet replaces board content with ships and validates resulting fleet, ships stay on the board if they don't
+// match the fleet or touch each other, but not if any of them is out of bounds, bent or overlaps another one
+func (b *Board) PlaceFleet(ships [][]data.Point[int]) error {
+	b.Clear()
+
+	placed := make([][]data.Point[int], 0, len(ships))
+	for _, ship := range ships {
+		if err := b.checkShip(ship); err != nil {
+			b.Clear()
+			return err
+		}
+
+		for _, pos := range ship {
+			b.cells[pos.Y][pos.X] = CellShip
+		}
+		placed = append(placed, append([]data.Point[int](nil), ship...))
+	}
+
+	b.ships = placed
+	return b.validateShips(placed)
+}
+
+// checkShip checks that ship is a straight line of empty cells without gaps
+func (b *Board) checkShip(ship []data.Point[int]) error {
+	if len(ship) == 0 {
+		return fmt.Errorf("%w: empty ship", ErrBadShip)
+	}
+
+	first, last := ship[0], ship[0]
+	for _, pos := range ship {
+		if !b.inBounds(pos.X, pos.Y) {
+			return fmt.Errorf("%w: ship at %d:%d", ErrOutOfBounds, pos.X, pos.Y)
+		}
+
+		if b.cells[pos.Y][pos.X] != CellEmpty {
+			return fmt.Errorf("%w: ships overlap at %d:%d", ErrBadShip, pos.X, pos.Y)
+		}
+
+		if pos.X < first.X || pos.Y < first.Y {
+			first = pos
+		}
+		if pos.X > last.X || pos.Y > last.Y {
+			last = pos
+		}
+	}
+
+	// Straight ship without gaps and repeated cells is as long as the distance between its ends
+	unique := make(map[data.Point[int]]bool, len(ship))
+	for _, pos := range ship {
+		unique[pos] = true
+	}
+	if !isStraight(ship) || len(unique) != len(ship) || last.X-first.X+last.Y-first.Y+1 != len(ship) {
+		return fmt.Errorf("%w: ship at %d:%d is not a straight line", ErrBadShip, ship[0].X, ship[0].Y)
+	}
+
+	return nil
+}
+
+func (b *Board) Ships() []data.Point[int] {
+	var ships []data.Point[int]
+	for y := 0; y < b.ruleSet.Height; y++ {
+		for x := 0; x < b.ruleSet.Width; x++ {
+			if b.cells[y][x] == CellShip {
+				ships = append(ships, data.NewPoint(x, y))
+			}
+		}
+	}
+
+	return ships
+}
+
+// ShipsCount returns number of placed ships of each length, ships longer than allowed by fleet are not counted
+func (b *Board) ShipsCount() []int {
+	ships := make([]int, b.ruleSet.Fleet.LongestShip())
+
+	if b.ships != nil {
+		for _, ship := range b.ships {
+			if len(ship) <= len(ships) {
+				ships[len(ship)-1]++
+			}
+		}
+		return ships
+	}
+
+	visited := newGrid[bool](b.ruleSet.Width, b.ruleSet.Height)
+
+	for y := 0; y < b.ruleSet.Height; y++ {
+		for x := 0; x < b.ruleSet.Width; x++ {
+			if visited[y][x] {
+				continue
+			}
+			visited[y][x] = true
+
+			if b.isShip(x, y) {
+				l := 1
+
+				dx := x + 1
+				for b.isShip(dx, y) {
+					visited[y][dx] = true
+					l++
+					dx++
+				}
+
+				if l == 1 {
+					dy := y + 1
+					for b.isShip(x, dy) {
+						visited[dy][x] = true
+						l++
+						dy++
+					}
+				}
+
+				if l <= len(ships) {
+					ships[l-1]++
+				}
+			}
+		}
+	}
+
+	return ships
+}
+
+// Validate checks that ships are straight, touch each other only as allowed by the rule set and exactly match the
+// fleet
+func (b *Board) Validate() error {
+	if b.ships != nil {
+		return b.validateShips(b.ships)
+	}
+
+	_, err := b.findShips()
+	return err
+}
+
+// FleetShips returns cells of each ship, when ships may touch they must be placed ship by ship or the whole fleet at
+// once, otherwise ships are found by their cells
+func (b *Board) FleetShips() ([][]data.Point[int], error) {
+	if b.ships != nil {
+		return append([][]data.Point[int](nil), b.ships...), nil
+	}
+
+	return b.findShips()
+}
+
+// validateShips checks that known ships touch each other only as allowed by the rule set and exactly match the fleet
+func (b *Board) validateShips(ships [][]data.Point[int]) error {
+	var around []data.Point[int]
+	switch b.ruleSet.Touching {
+	case TouchingNone:
+		around = neighbours
+	case TouchingCorners:
+		around = neighbours[:4]
+	}
+
+	// Number of ship that occupies the cell, starting from one
+	owners := newGrid[int](b.ruleSet.Width, b.ruleSet.Height)
+	for i, ship := range ships {
+		for _, pos := range ship {
+			owners[pos.Y][pos.X] = i + 1
+		}
+	}
+
+	counts := make([]int, b.ruleSet.Fleet.LongestShip())
+	for i, ship := range ships {
+		for _, pos := range ship {
+			for _, dir := range around {
+				next := pos.Add(dir)
+				if b.InBounds(next) && owners[next.Y][next.X] != 0 && owners[next.Y][next.X] != i+1 {
+					return fmt.Errorf("%w: at %d:%d", ErrShipsTouching, pos.X, pos.Y)
+				}
+			}
+		}
+
+		l := len(ship)
+		if l > len(counts) {
+			return fmt.Errorf("%w: %d at %d:%d", ErrShipTooLong, l, ship[0].X, ship[0].Y)
+		}
+		counts[l-1]++
+	}
+
+	if !b.ruleSet.Fleet.Matches(counts) {
+		return fmt.Errorf("%w: %v, expected %v", ErrWrongFleet, counts, b.ruleSet.Fleet)
+	}
+
+	return nil
+}
+
+// findShips finds ships by cells connected by sides and validates them, ships that may touch can't be told apart
+func (b *Board) findShips() ([][]data.Point[int], error) {
+	if b.ruleSet.Touching == TouchingFree {
+		return nil, ErrShipsUnknown
+	}
+
+	var ships [][]data.Point[int]
+	counts := make([]int, b.ruleSet.Fleet.LongestShip())
+	visited := newGrid[bool](b.ruleSet.Width, b.ruleSet.Height)
+	for y := 0; y < b.ruleSet.Height; y++ {
+		for x := 0; x < b.ruleSet.Width; x++ {
+			if visited[y][x] || !b.isShip(x, y) {
+				continue
+			}
+
+			if b.ruleSet.Touching == TouchingNone &&
+				(b.isShip(x-1, y-1) || b.isShip(x+1, y-1) || b.isShip(x-1, y+1) || b.isShip(x+1, y+1)) {
+				return nil, fmt.Errorf("%w: at %d:%d", ErrShipsTouching, x, y)
+			}
+
+			ship := b.connectedShip(x, y)
+			for _, pos := range ship {
+				visited[pos.Y][pos.X] = true
+			}
+
+			// Not straight ship is two ships touching by sides
+			if !isStraight(ship) {
+				return nil, fmt.Errorf("%w: at %d:%d", ErrShipsTouching, x, y)
+			}
+
+			l := len(ship)
+			if l > len(counts) {
+				return nil, fmt.Errorf("%w: %d at %d:%d", ErrShipTooLong, l, x, y)
+			}
+			counts[l-1]++
+
+			ships = append(ships, ship)
+		}
+	}
+
+	if !b.ruleSet.Fleet.Matches(counts) {
+		return nil, fmt.Errorf("%w: %v, expected %v", ErrWrongFleet, counts, b.ruleSet.Fleet)
+	}
+
+	return ships, nil
+}
+
+func (b *Board) CanShoot(pos data.Point[int]) bool {
+	return b.InBounds(pos) && (b.At(pos) == CellEmpty || b.At(pos) == CellShip)
+}
+
+// Shoot resolves shot at position of board with known ships
+func (b *Board) Shoot(pos data.Point[int]) (ShotResult, error) {
+	if !b.InBounds(pos) {
+		return 0, fmt.Errorf("%w: shot at %d:%d", ErrOutOfBounds, pos.X, pos.Y)
+	}
+
+	if !b.CanShoot(pos) {
+		return 0, ErrAlreadyShot
+	}
+
+	result := ShotMiss
+	if b.At(pos) == CellShip {
+		result = ShotHit
+	}
+
+	b.Mark(pos, result)
+	if result == ShotHit && b.FillIfDestroyed(pos) {
+		result = ShotDestroyed
+	}
+
+	return result, nil
+}
+
+// Mark applies already known shot result to the board
+func (b *Board) Mark(pos data.Point[int], result ShotResult) {
+	switch result {
+	case ShotMiss:
+		b.Set(pos, CellMiss)
+	case ShotHit:
+		b.Set(pos, CellShipHit)
+	case ShotDestroyed:
+		b.Set(pos, CellShipHit)
+		_ = b.FillIfDestroyed(pos)
+	default:
+		panic(fmt.Sprintf("unknown shot result: %d", result))
+	}
+}
+
+// FillIfDestroyed marks cells around ship at position as missed if whole ship was hit, cells where touching ships
+// may be are left as is
+func (b *Board) FillIfDestroyed(pos data.Point[int]) bool {
+	if b.At(pos) != CellShipHit {
+		return false
+	}
+
+	ship := b.shipAt(pos)
+	for _, cell := range ship {
+		if b.At(cell) != CellShipHit {
+			return false
+		}
+	}
+
+	var around []data.Point[int]
+	switch b.ruleSet.Touching {
+	case TouchingNone:
+		around = neighbours
+	case TouchingCorners:
+		around = neighbours[:4]
+	}
+
+	for _, cell := range ship {
+		for _, dir := range around {
+			if next := cell.Add(dir); b.InBounds(next) && b.At(next) == CellEmpty {
+				b.Set(next, CellMiss)
+			}
+		}
+	}
+
+	return true
+}
+
+// shipAt returns cells of ship at position, if fleet is not known, the longest straight line of ship cells going
+// through position is used
+func (b *Board) shipAt(pos data.Point[int]) []data.Point[int] {
+	for _, ship := range b.ships {
+		for _, cell := range ship {
+			if cell == pos {
+				return ship
+			}
+		}
+	}
+
+	horizontal := b.lineAt(pos, data.NewPoint(1, 0))
+	vertical := b.lineAt(pos, data.NewPoint(0, 1))
+	if len(vertical) > len(horizontal) {
+		return vertical
+	}
+
+	return horizontal
+}
+
+// lineAt returns straight line of ship cells, hit or not, going through position in direction
+func (b *Board) lineAt(pos, dir data.Point[int]) []data.Point[int] {
+	start := pos
+	for b.isShipOrHit(start.Sub(dir)) {
+		start = start.Sub(dir)
+	}
+
+	var line []data.Point[int]
+	for cell := start; b.isShipOrHit(cell); cell = cell.Add(dir) {
+		line = append(line, cell)
+	}
+
+	return line
+}
+
+// connectedShip returns ship cells connected to position by sides
+func (b *Board) connectedShip(x, y int) []data.Point[int] {
+	ship := []data.Point[int]{data.NewPoint(x, y)}
+	visited := map[data.Point[int]]bool{ship[0]: true}
+
+	for i := 0; i < len(ship); i++ {
+		for _, dir := range neighbours[:4] {
+			next := ship[i].Add(dir)
+			if !visited[next] && b.isShip(next.X, next.Y) {
+				visited[next] = true
+				ship = append(ship, next)
+			}
+		}
+	}
+
+	return ship
+}
+
+// FleetCells returns cells of all ships, hit or not
+func (b *Board) FleetCells() []data.Point[int] {
+	var cells []data.Point[int]
+	for y := 0; y < b.ruleSet.Height; y++ {
+		for x := 0; x < b.ruleSet.Width; x++ {
+			if b.isShipOrHit(data.NewPoint(x, y)) {
+				cells = append(cells, data.NewPoint(x, y))
+			}
+		}
+	}
+
+	return cells
+}
+
+// AliveShips returns number of ships that are not destroyed yet
+func (b *Board) AliveShips() int {
+	alive := map[data.Point[int]]bool{}
+	for y := 0; y < b.ruleSet.Height; y++ {
+		for x := 0; x < b.ruleSet.Width; x++ {
+			if b.cells[y][x] == CellShip {
+				alive[b.shipAt(data.NewPoint(x, y))[0]] = true
+			}
+		}
+	}
+
+	return len(alive)
+}
+
+// ShootableCells returns number of cells that were not shot yet
+func (b *Board) ShootableCells() int {
+	cells := 0
+	for y := 0; y < b.ruleSet.Height; y++ {
+		for x := 0; x < b.ruleSet.Width; x++ {
+			if b.CanShoot(data.NewPoint(x, y)) {
+				cells++
+			}
+		}
+	}
+
+	return cells
+}
+
+// ShotsPerTurn returns number of shots player with own board fires at target board in one turn
+func ShotsPerTurn(own, target *Board) int {
+	shots := own.ruleSet.Shots(own.AliveShips())
+	if cells := target.ShootableCells(); shots > cells {
+		return cells
+	}
+
+	return shots
+}
+
+// ValidateSalvo checks that salvo fired from own board has the right number of different shots at target board cells
+// that were not shot yet
+func ValidateSalvo(own, target *Board, targets []data.Point[int]) error {
+	if shots := ShotsPerTurn(own, target); len(targets) != shots {
+		return fmt.Errorf("salvo of %d shots, expected %d", len(targets), shots)
+	}
+
+	unique := make(map[data.Point[int]]bool, len(targets))
+	for _, pos := range targets {
+		if !target.InBounds(pos) {
+			return fmt.Errorf("%w: shot at %d:%d", ErrOutOfBounds, pos.X, pos.Y)
+		}
+
+		if !target.CanShoot(pos) || unique[pos] {
+			return fmt.Errorf("%w: at %d:%d", ErrAlreadyShot, pos.X, pos.Y)
+		}
+		unique[pos] = true
+	}
+
+	return nil
+}
+
+func (b *Board) HasAlive() bool {
+	for y := 0; y < b.ruleSet.Height; y++ {
+		for x := 0; x < b.ruleSet.Width; x++ {
+			if b.cells[y][x] == CellShip {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (b *Board) inBounds(x, y int) bool {
+	return 0 <= x && x < b.ruleSet.Width && 0 <= y && y < b.ruleSet.Height
+}
+
+func (b *Board) at(x, y int) Cell {
+	if !b.inBounds(x, y) {
+		return CellEmpty
+	}
+	return b.cells[y][x]
+}
+
+func (b *Board) isShip(x, y int) bool {
+	return b.at(x, y) == CellShip
+}
+
+func (b *Board) isShipOrHit(pos data.Point[int]) bool {
+	cell := b.at(pos.X, pos.Y)
+	return cell == CellShip || cell == CellShipHit
+}
+
+// isStraight reports whether all cells are in the same row or column
+func isStraight(cells []data.Point[int]) bool {
+	sameRow, sameColumn := true, true
+	for _, cell := range cells {
+		sameRow = sameRow && cell.Y == cells[0].Y
+		sameColumn = sameColumn && cell.X == cells[0].X
+	}
+
+	return sameRow || sameColumn
+}
+
+func newGrid[T any](width, height int) [][]T {
+	grid := make([][]T, height)
+	for y := range grid {
+		grid[y] = make([]T, width)
+	}
+	return grid
+}
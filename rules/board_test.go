@@ -0,0 +1,463 @@
+package rules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mymmrac/battleship/data"
+)
+
+// testRuleSet is a small rule set with one ship of each length from 1 to 3
+func testRuleSet(touching Touching) RuleSet {
+	return RuleSet{Name: "Test", Width: 6, Height: 6, Fleet: Fleet{1, 1, 1}, Touching: touching}
+}
+
+func pt(x, y int) data.Point[int] {
+	return data.NewPoint(x, y)
+}
+
+// testFleet is a valid fleet of testRuleSet for any touching rule
+func testFleet() [][]data.Point[int] {
+	return [][]data.Point[int]{
+		{pt(0, 0), pt(1, 0), pt(2, 0)},
+		{pt(4, 2), pt(4, 3)},
+		{pt(1, 5)},
+	}
+}
+
+func fleetCells(ships [][]data.Point[int]) []data.Point[int] {
+	var cells []data.Point[int]
+	for _, ship := range ships {
+		cells = append(cells, ship...)
+	}
+
+	return cells
+}
+
+func newTestBoard(t *testing.T, touching Touching, ships []data.Point[int]) *Board {
+	t.Helper()
+
+	board := NewBoard(testRuleSet(touching))
+	for _, pos := range ships {
+		board.Set(pos, CellShip)
+	}
+
+	return board
+}
+
+func TestBoardCanPlace(t *testing.T) {
+	tests := []struct {
+		name     string
+		touching Touching
+		ships    []data.Point[int]
+		pos      data.Point[int]
+		want     bool
+	}{
+		{name: "empty board", touching: TouchingNone, pos: pt(0, 0), want: true},
+		{name: "out of bounds", touching: TouchingNone, pos: pt(6, 0), want: false},
+		{name: "negative position", touching: TouchingNone, pos: pt(0, -1), want: false},
+		{name: "occupied", touching: TouchingNone, ships: []data.Point[int]{pt(2, 2)}, pos: pt(2, 2), want: false},
+		{name: "extends ship", touching: TouchingNone, ships: []data.Point[int]{pt(2, 2)}, pos: pt(3, 2), want: true},
+		{
+			name:     "ship longer than fleet allows",
+			touching: TouchingNone,
+			ships:    []data.Point[int]{pt(1, 2), pt(2, 2), pt(3, 2)},
+			pos:      pt(4, 2),
+			want:     false,
+		},
+		{
+			name:     "bent ship",
+			touching: TouchingCorners,
+			ships:    []data.Point[int]{pt(2, 2), pt(3, 2)},
+			pos:      pt(3, 3),
+			want:     false,
+		},
+		{
+			name:     "corner, no touching",
+			touching: TouchingNone,
+			ships:    []data.Point[int]{pt(2, 2)},
+			pos:      pt(3, 3),
+			want:     false,
+		},
+		{
+			name:     "corner, corners touching",
+			touching: TouchingCorners,
+			ships:    []data.Point[int]{pt(2, 2)},
+			pos:      pt(3, 3),
+			want:     true,
+		},
+		{
+			name:     "bent ship, free touching",
+			touching: TouchingFree,
+			ships:    []data.Point[int]{pt(2, 2), pt(3, 2)},
+			pos:      pt(3, 3),
+			want:     true,
+		},
+		{
+			name:     "all fleet cells placed",
+			touching: TouchingFree,
+			ships:    fleetCells(testFleet()),
+			pos:      pt(5, 5),
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := newTestBoard(t, tt.touching, tt.ships)
+			if got := board.CanPlace(tt.pos); got != tt.want {
+				t.Errorf("CanPlace(%v) = %t, want %t", tt.pos, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoardPlaceFleet(t *testing.T) {
+	tests := []struct {
+		name     string
+		touching Touching
+		ships    [][]data.Point[int]
+		wantErr  error
+	}{
+		{name: "valid", touching: TouchingNone, ships: testFleet()},
+		{
+			name:     "out of bounds",
+			touching: TouchingNone,
+			ships:    append(testFleet()[:2], []data.Point[int]{pt(1, 6)}),
+			wantErr:  ErrOutOfBounds,
+		},
+		{name: "missing ship", touching: TouchingNone, ships: testFleet()[:2], wantErr: ErrWrongFleet},
+		{
+			name:     "ship too long",
+			touching: TouchingNone,
+			ships: [][]data.Point[int]{
+				testFleet()[0],
+				{pt(2, 3), pt(3, 3), pt(4, 3), pt(5, 3)},
+				testFleet()[2],
+			},
+			wantErr: ErrShipTooLong,
+		},
+		{
+			name:     "bent ship",
+			touching: TouchingFree,
+			ships:    append(testFleet()[:1], []data.Point[int]{pt(4, 2), pt(4, 3), pt(5, 3)}),
+			wantErr:  ErrBadShip,
+		},
+		{
+			name:     "ship with gap",
+			touching: TouchingFree,
+			ships:    append(testFleet()[:1], []data.Point[int]{pt(4, 2), pt(4, 4)}, testFleet()[2]),
+			wantErr:  ErrBadShip,
+		},
+		{name: "empty ship", touching: TouchingFree, ships: append(testFleet(), nil), wantErr: ErrBadShip},
+		{
+			name:     "ships overlap",
+			touching: TouchingFree,
+			ships:    append(testFleet()[:2], []data.Point[int]{pt(2, 0)}),
+			wantErr:  ErrBadShip,
+		},
+		{
+			name:     "touching by corners",
+			touching: TouchingNone,
+			ships:    append(testFleet()[:2], []data.Point[int]{pt(3, 4)}),
+			wantErr:  ErrShipsTouching,
+		},
+		{
+			name:     "corners touching allowed",
+			touching: TouchingCorners,
+			ships:    append(testFleet()[:2], []data.Point[int]{pt(3, 4)}),
+		},
+		{
+			name:     "touching by sides",
+			touching: TouchingCorners,
+			ships:    append(testFleet()[:2], []data.Point[int]{pt(3, 3)}),
+			wantErr:  ErrShipsTouching,
+		},
+		{
+			name:     "sides touching allowed",
+			touching: TouchingFree,
+			ships:    append(testFleet()[:2], []data.Point[int]{pt(3, 3)}),
+		},
+		{
+			name:     "ships in one line",
+			touching: TouchingFree,
+			ships: [][]data.Point[int]{
+				{pt(0, 0)},
+				{pt(1, 0), pt(2, 0), pt(3, 0)},
+				{pt(4, 0), pt(5, 0)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := NewBoard(testRuleSet(tt.touching))
+			err := board.PlaceFleet(tt.ships)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlaceFleet() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// Malformed ships are not placed at all
+			wantCells := len(fleetCells(tt.ships))
+			if errors.Is(err, ErrBadShip) || errors.Is(err, ErrOutOfBounds) {
+				wantCells = 0
+			}
+			if len(board.Ships()) != wantCells {
+				t.Errorf("%d ship cells placed, want %d", len(board.Ships()), wantCells)
+			}
+
+			if err != nil {
+				return
+			}
+
+			for _, ship := range tt.ships {
+				if got := board.ShipAt(ship[0]); len(got) != len(ship) {
+					t.Errorf("ShipAt(%v) has %d cells, want %d", ship[0], len(got), len(ship))
+				}
+			}
+		})
+	}
+}
+
+func TestBoardFleetShips(t *testing.T) {
+	board := newTestBoard(t, TouchingCorners, fleetCells(testFleet()))
+	ships, err := board.FleetShips()
+	if err != nil {
+		t.Fatalf("FleetShips() error = %v", err)
+	}
+	if len(ships) != len(testFleet()) {
+		t.Errorf("FleetShips() found %d ships, want %d", len(ships), len(testFleet()))
+	}
+
+	// Ships that may touch can't be told apart by their cells
+	board = newTestBoard(t, TouchingFree, fleetCells(testFleet()))
+	if _, err = board.FleetShips(); !errors.Is(err, ErrShipsUnknown) {
+		t.Errorf("FleetShips() error = %v, want %v", err, ErrShipsUnknown)
+	}
+
+	if err = board.PlaceFleet(testFleet()); err != nil {
+		t.Fatal(err)
+	}
+	if ships, err = board.FleetShips(); err != nil || len(ships) != len(testFleet()) {
+		t.Errorf("FleetShips() = %d ships, error = %v, want %d ships", len(ships), err, len(testFleet()))
+	}
+}
+
+func TestBoardShoot(t *testing.T) {
+	tests := []struct {
+		name    string
+		shots   []data.Point[int]
+		pos     data.Point[int]
+		want    ShotResult
+		wantErr error
+	}{
+		{name: "miss", pos: pt(5, 5), want: ShotMiss},
+		{name: "hit", pos: pt(0, 0), want: ShotHit},
+		{name: "hit in the middle", shots: []data.Point[int]{pt(0, 0)}, pos: pt(1, 0), want: ShotHit},
+		{
+			name:  "destroyed in the middle",
+			shots: []data.Point[int]{pt(0, 0), pt(2, 0)},
+			pos:   pt(1, 0),
+			want:  ShotDestroyed,
+		},
+		{name: "single cell ship", pos: pt(1, 5), want: ShotDestroyed},
+		{name: "the last ship cell", shots: []data.Point[int]{pt(4, 2)}, pos: pt(4, 3), want: ShotDestroyed},
+		{name: "already missed", shots: []data.Point[int]{pt(5, 5)}, pos: pt(5, 5), wantErr: ErrAlreadyShot},
+		{name: "already hit", shots: []data.Point[int]{pt(0, 0)}, pos: pt(0, 0), wantErr: ErrAlreadyShot},
+		{
+			name:    "filled around destroyed",
+			shots:   []data.Point[int]{pt(1, 5)},
+			pos:     pt(0, 4),
+			wantErr: ErrAlreadyShot,
+		},
+		{name: "out of bounds", pos: pt(-1, 0), wantErr: ErrOutOfBounds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := NewBoard(testRuleSet(TouchingNone))
+			if err := board.PlaceFleet(testFleet()); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, pos := range tt.shots {
+				if _, err := board.Shoot(pos); err != nil {
+					t.Fatalf("Shoot(%v) error = %v", pos, err)
+				}
+			}
+
+			got, err := board.Shoot(tt.pos)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Shoot(%v) error = %v, want %v", tt.pos, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Shoot(%v) = %d, want %d", tt.pos, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoardFillIfDestroyed(t *testing.T) {
+	tests := []struct {
+		name      string
+		touching  Touching
+		board     string
+		want      bool
+		wantBoard string
+	}{
+		{
+			name:     "not destroyed",
+			touching: TouchingNone,
+			board: `
+				......
+				......
+				..x#..
+				......
+				......
+				......`,
+			want: false,
+			wantBoard: `
+				......
+				......
+				..x#..
+				......
+				......
+				......`,
+		},
+		{
+			name:     "no touching",
+			touching: TouchingNone,
+			board: `
+				......
+				......
+				..xx..
+				......
+				......
+				......`,
+			want: true,
+			wantBoard: `
+				......
+				.oooo.
+				.oxxo.
+				.oooo.
+				......
+				......`,
+		},
+		{
+			name:     "corners touching",
+			touching: TouchingCorners,
+			board: `
+				......
+				......
+				..xx..
+				....#.
+				......
+				......`,
+			want: true,
+			wantBoard: `
+				......
+				..oo..
+				.oxxo.
+				..oo#.
+				......
+				......`,
+		},
+		{
+			name:     "free touching",
+			touching: TouchingFree,
+			board: `
+				......
+				......
+				..xx..
+				..##..
+				......
+				......`,
+			want: true,
+			wantBoard: `
+				......
+				......
+				..xx..
+				..##..
+				......
+				......`,
+		},
+		{
+			name:     "at the edge",
+			touching: TouchingNone,
+			board: `
+				x.....
+				x.....
+				......
+				......
+				......
+				......`,
+			want: true,
+			wantBoard: `
+				xo....
+				xo....
+				oo....
+				......
+				......
+				......`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := ParseBoard(testRuleSet(tt.touching), tt.board)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wantBoard, err := ParseBoard(testRuleSet(tt.touching), tt.wantBoard)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The first hit cell of the board is the one just hit
+			var pos data.Point[int]
+			for _, cell := range board.FleetCells() {
+				if board.At(cell) == CellShipHit {
+					pos = cell
+					break
+				}
+			}
+
+			if got := board.FillIfDestroyed(pos); got != tt.want {
+				t.Fatalf("FillIfDestroyed(%v) = %t, want %t", pos, got, tt.want)
+			}
+
+			if board.String() != wantBoard.String() {
+				t.Errorf("FillIfDestroyed(%v) board:\n%swant:\n%s", pos, board, wantBoard)
+			}
+		})
+	}
+}
+
+func TestBoardHasAlive(t *testing.T) {
+	board := NewBoard(testRuleSet(TouchingNone))
+	if board.HasAlive() {
+		t.Fatal("empty board has alive ships")
+	}
+
+	if err := board.PlaceFleet(testFleet()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, pos := range fleetCells(testFleet()) {
+		if !board.HasAlive() {
+			t.Fatalf("no alive ships after %d hits", i)
+		}
+
+		if _, err := board.Shoot(pos); err != nil {
+			t.Fatalf("Shoot(%v) error = %v", pos, err)
+		}
+	}
+
+	if board.HasAlive() {
+		t.Error("alive ships after the whole fleet is destroyed")
+	}
+	if alive := board.AliveShips(); alive != 0 {
+		t.Errorf("AliveShips() = %d, want 0", alive)
+	}
+}
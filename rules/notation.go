@@ -0,0 +1,205 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mymmrac/battleship/data"
+)
+
+// Board notation is a line of cells per board row, each cell is one of:
+//
+//	.  empty
+//	#  ship
+//	o  miss
+//	x  hit ship
+const (
+	NotationEmpty   = '.'
+	NotationShip    = '#'
+	NotationMiss    = 'o'
+	NotationShipHit = 'x'
+)
+
+var ErrBadNotation = errors.New("bad notation")
+
+// String formats board in board notation, rows are separated by new lines
+func (b *Board) String() string {
+	var sb strings.Builder
+	sb.Grow((b.ruleSet.Width + 1) * b.ruleSet.Height)
+
+	for y := 0; y < b.ruleSet.Height; y++ {
+		for x := 0; x < b.ruleSet.Width; x++ {
+			sb.WriteByte(cellNotation(b.cells[y][x]))
+		}
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// ParseBoard parses board in board notation, surrounding whitespace and empty lines are ignored, size of the board
+// must match the rule set, ships are not validated
+func ParseBoard(ruleSet RuleSet, text string) (*Board, error) {
+	var rows []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			rows = append(rows, line)
+		}
+	}
+
+	if len(rows) != ruleSet.Height {
+		return nil, fmt.Errorf("%w: %d rows, expected %d", ErrBadNotation, len(rows), ruleSet.Height)
+	}
+
+	board := NewBoard(ruleSet)
+	for y, row := range rows {
+		if len(row) != ruleSet.Width {
+			return nil, fmt.Errorf("%w: %d cells in row %d, expected %d", ErrBadNotation, len(row), y+1, ruleSet.Width)
+		}
+
+		for x := 0; x < len(row); x++ {
+			cell, ok := notationCell(row[x])
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown cell %q at %s", ErrBadNotation, row[x], FormatPos(data.NewPoint(x, y)))
+			}
+			board.cells[y][x] = cell
+		}
+	}
+
+	return board, nil
+}
+
+func cellNotation(cell Cell) byte {
+	switch cell {
+	case CellEmpty:
+		return NotationEmpty
+	case CellShip:
+		return NotationShip
+	case CellMiss:
+		return NotationMiss
+	case CellShipHit:
+		return NotationShipHit
+	default:
+		panic(fmt.Sprintf("unknown cell: %d", cell))
+	}
+}
+
+func notationCell(c byte) (Cell, bool) {
+	switch c {
+	case NotationEmpty:
+		return CellEmpty, true
+	case NotationShip:
+		return CellShip, true
+	case NotationMiss:
+		return CellMiss, true
+	case NotationShipHit:
+		return CellShipHit, true
+	default:
+		return 0, false
+	}
+}
+
+// FormatShips formats ships in fleet notation: ships separated by spaces, each ship is its first and last cells
+// joined by dash, single cell ship is just its cell, for example: A1-A4 C3-E3 H8
+func FormatShips(ships [][]data.Point[int]) string {
+	parts := make([]string, 0, len(ships))
+	for _, ship := range ships {
+		if len(ship) == 0 {
+			continue
+		}
+
+		first, last := ship[0], ship[0]
+		for _, pos := range ship {
+			if pos.X < first.X || pos.Y < first.Y {
+				first = pos
+			}
+			if pos.X > last.X || pos.Y > last.Y {
+				last = pos
+			}
+		}
+
+		if first == last {
+			parts = append(parts, FormatPos(first))
+		} else {
+			parts = append(parts, FormatPos(first)+"-"+FormatPos(last))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// ParseShips parses ships in fleet notation, each ship must be a horizontal or vertical line on the board of the rule
+// set, ships are not validated
+func ParseShips(ruleSet RuleSet, text string) ([][]data.Point[int], error) {
+	parts := strings.Fields(text)
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("%w: no ships", ErrBadNotation)
+	}
+
+	ships := make([][]data.Point[int], 0, len(parts))
+	for _, part := range parts {
+		firstText, lastText, found := strings.Cut(part, "-")
+		if !found {
+			lastText = firstText
+		}
+
+		first, err := ParsePos(firstText)
+		if err != nil {
+			return nil, err
+		}
+
+		last, err := ParsePos(lastText)
+		if err != nil {
+			return nil, err
+		}
+
+		if first.X != last.X && first.Y != last.Y || first.X > last.X || first.Y > last.Y {
+			return nil, fmt.Errorf("%w: ship %q", ErrBadNotation, part)
+		}
+
+		if first.X < 0 || first.Y < 0 || last.X >= ruleSet.Width || last.Y >= ruleSet.Height {
+			return nil, fmt.Errorf("%w: ship %q", ErrOutOfBounds, part)
+		}
+
+		vertical := first.X == last.X && first.Y != last.Y
+		ships = append(ships, ShipCells(first, last.X-first.X+last.Y-first.Y+1, vertical))
+	}
+
+	return ships, nil
+}
+
+// FormatPos formats zero based position as column name followed by one based row number, for example: A1, B7, AC30
+func FormatPos(pos data.Point[int]) string {
+	return ColumnName(pos.X) + strconv.Itoa(pos.Y+1)
+}
+
+// ParsePos parses position formatted by FormatPos, column letters are case-insensitive, positions outside of the
+// biggest board are rejected
+func ParsePos(text string) (data.Point[int], error) {
+	text = strings.ToUpper(strings.TrimSpace(text))
+
+	letters := 0
+	column := 0
+	for letters < len(text) && 'A' <= text[letters] && text[letters] <= 'Z' {
+		column = column*26 + int(text[letters]-'A') + 1
+		letters++
+
+		// Stop before long column names overflow
+		if column > MaxBoardSize {
+			return data.Point[int]{}, fmt.Errorf("%w: position %q", ErrBadNotation, text)
+		}
+	}
+
+	if letters == 0 || letters == len(text) {
+		return data.Point[int]{}, fmt.Errorf("%w: position %q", ErrBadNotation, text)
+	}
+
+	row, err := strconv.Atoi(text[letters:])
+	if err != nil || row < 1 || row > MaxBoardSize || text[letters] == '+' {
+		return data.Point[int]{}, fmt.Errorf("%w: position %q", ErrBadNotation, text)
+	}
+
+	return data.NewPoint(column-1, row-1), nil
+}
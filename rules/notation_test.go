@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mymmrac/battleship/data"
+)
+
+func TestBoardNotationRoundTrip(t *testing.T) {
+	board := NewBoard(testRuleSet(TouchingNone))
+	if err := board.PlaceFleet(testFleet()); err != nil {
+		t.Fatal(err)
+	}
+	for _, pos := range []data.Point[int]{pt(0, 0), pt(1, 5), pt(5, 5)} {
+		if _, err := board.Shoot(pos); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := "" +
+		"x##...\n" +
+		"......\n" +
+		"....#.\n" +
+		"....#.\n" +
+		"ooo...\n" +
+		"oxo..o\n"
+	if got := board.String(); got != want {
+		t.Fatalf("String() =\n%swant:\n%s", got, want)
+	}
+
+	parsed, err := ParseBoard(board.RuleSet(), "\n  "+strings.ReplaceAll(want, "\n", "\n  ")+"\n")
+	if err != nil {
+		t.Fatalf("ParseBoard() error = %v", err)
+	}
+	if got := parsed.String(); got != want {
+		t.Errorf("ParseBoard(String()) =\n%swant:\n%s", got, want)
+	}
+}
+
+func TestParseBoardErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "empty", text: ""},
+		{name: "missing row", text: "......\n......\n......\n......\n......"},
+		{name: "short row", text: "......\n......\n.....\n......\n......\n......"},
+		{name: "long row", text: "......\n......\n.......\n......\n......\n......"},
+		{name: "unknown cell", text: "......\n......\n..?...\n......\n......\n......"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseBoard(testRuleSet(TouchingNone), tt.text); !errors.Is(err, ErrBadNotation) {
+				t.Errorf("ParseBoard() error = %v, want %v", err, ErrBadNotation)
+			}
+		})
+	}
+}
+
+func TestFormatPos(t *testing.T) {
+	tests := []struct {
+		pos  data.Point[int]
+		want string
+	}{
+		{pos: pt(0, 0), want: "A1"},
+		{pos: pt(1, 6), want: "B7"},
+		{pos: pt(25, 9), want: "Z10"},
+		{pos: pt(26, 0), want: "AA1"},
+		{pos: pt(28, 29), want: "AC30"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatPos(tt.pos); got != tt.want {
+			t.Errorf("FormatPos(%v) = %q, want %q", tt.pos, got, tt.want)
+		}
+
+		pos, err := ParsePos(tt.want)
+		if err != nil || pos != tt.pos {
+			t.Errorf("ParsePos(%q) = %v, error = %v, want %v", tt.want, pos, err, tt.pos)
+		}
+	}
+}
+
+func TestParsePos(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    data.Point[int]
+		wantErr bool
+	}{
+		{text: "ac30", want: pt(28, 29)},
+		{text: " b7 ", want: pt(1, 6)},
+		{text: "", wantErr: true},
+		{text: "A", wantErr: true},
+		{text: "7", wantErr: true},
+		{text: "7B", wantErr: true},
+		{text: "A0", wantErr: true},
+		{text: "A-1", wantErr: true},
+		{text: "A+1", wantErr: true},
+		{text: "A1B", wantErr: true},
+		{text: "Б1", wantErr: true},
+		{text: "AE1", wantErr: true},
+		{text: "A31", wantErr: true},
+		{text: "ZZZZZZZZZZZZZZ1", wantErr: true},
+		{text: "A99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePos(tt.text)
+		if tt.wantErr {
+			if !errors.Is(err, ErrBadNotation) {
+				t.Errorf("ParsePos(%q) error = %v, want %v", tt.text, err, ErrBadNotation)
+			}
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Errorf("ParsePos(%q) = %v, error = %v, want %v", tt.text, got, err, tt.want)
+		}
+	}
+}
+
+func TestFormatShips(t *testing.T) {
+	if got, want := FormatShips(testFleet()), "A1-C1 E3-E4 B6"; got != want {
+		t.Errorf("FormatShips() = %q, want %q", got, want)
+	}
+}
+
+func TestParseShips(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    [][]data.Point[int]
+		wantErr error
+	}{
+		{name: "fleet", text: "A1-C1 E3-E4 B6", want: testFleet()},
+		{
+			name: "extra spaces",
+			text: "  b6\tA1-C1\n",
+			want: [][]data.Point[int]{{pt(1, 5)}, {pt(0, 0), pt(1, 0), pt(2, 0)}},
+		},
+		{name: "empty", text: " ", wantErr: ErrBadNotation},
+		{name: "bad position", text: "A1-C", wantErr: ErrBadNotation},
+		{name: "diagonal", text: "A1-B2", wantErr: ErrBadNotation},
+		{name: "reversed", text: "C1-A1", wantErr: ErrBadNotation},
+		{name: "out of bounds", text: "A1-A7", wantErr: ErrOutOfBounds},
+		{name: "long column name", text: "ZZZZZZZZZZZZZZ1-A1", wantErr: ErrBadNotation},
+		{name: "outside of any board", text: "A1-AE1", wantErr: ErrBadNotation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseShips(testRuleSet(TouchingNone), tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseShips(%q) error = %v, want %v", tt.text, err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseShips(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/mymmrac/battleship/data"
+)
+
+const (
+	randomShipAttempts  = 100
+	randomFleetAttempts = 1000
+)
+
+// PlaceRandomFleet replaces board content with board's fleet placed at random positions, ships touch each other
+// only as allowed by the rule set
+func (b *Board) PlaceRandomFleet(rng *rand.Rand) error {
+	for attempt := 0; attempt < randomFleetAttempts; attempt++ {
+		b.Clear()
+
+		ships, ok := b.placeRandomShips(rng)
+		if ok && b.validateShips(ships) == nil {
+			b.ships = ships
+			return nil
+		}
+	}
+
+	b.Clear()
+	return fmt.Errorf("%w: %s on %dx%d board", ErrFleetDoesNotFit, b.ruleSet.Fleet, b.ruleSet.Width, b.ruleSet.Height)
+}
+
+func (b *Board) placeRandomShips(rng *rand.Rand) ([][]data.Point[int], bool) {
+	ships := make([][]data.Point[int], 0, len(b.ruleSet.Fleet.Lengths()))
+	for _, length := range b.ruleSet.Fleet.Lengths() {
+		ship, ok := b.placeRandomShip(rng, length)
+		if !ok {
+			return nil, false
+		}
+		ships = append(ships, ship)
+	}
+
+	return ships, true
+}
+
+func (b *Board) placeRandomShip(rng *rand.Rand, length int) ([]data.Point[int], bool) {
+	for attempt := 0; attempt < randomShipAttempts; attempt++ {
+		dir := data.NewPoint(1, 0)
+		if rng.Intn(2) == 0 {
+			dir = data.NewPoint(0, 1)
+		}
+
+		width, height := b.ruleSet.Width-dir.X*(length-1), b.ruleSet.Height-dir.Y*(length-1)
+		if width <= 0 || height <= 0 {
+			continue
+		}
+
+		x := rng.Intn(width)
+		y := rng.Intn(height)
+
+		if !b.canPlaceShip(x, y, x+dir.X*(length-1), y+dir.Y*(length-1)) {
+			continue
+		}
+
+		ship := make([]data.Point[int], 0, length)
+		for j := 0; j < length; j++ {
+			b.cells[y+dir.Y*j][x+dir.X*j] = CellShip
+			ship = append(ship, data.NewPoint(x+dir.X*j, y+dir.Y*j))
+		}
+		return ship, true
+	}
+
+	return nil, false
+}
+
+// canPlaceShip reports whether straight ship from the first to the last cell doesn't touch other ships in a way
+// forbidden by the rule set
+func (b *Board) canPlaceShip(x1, y1, x2, y2 int) bool {
+	switch b.ruleSet.Touching {
+	case TouchingNone:
+		return b.isAreaFree(x1-1, y1-1, x2+1, y2+1)
+	case TouchingCorners:
+		return b.isAreaFree(x1-1, y1, x2+1, y2) && b.isAreaFree(x1, y1-1, x2, y2+1)
+	default:
+		return b.isAreaFree(x1, y1, x2, y2)
+	}
+}
+
+// isAreaFree reports whether there are no ships in rectangle, cells out of bounds are ignored
+func (b *Board) isAreaFree(x1, y1, x2, y2 int) bool {
+	for y := y1; y <= y2; y++ {
+		for x := x1; x <= x2; x++ {
+			if b.isShip(x, y) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
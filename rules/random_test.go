@@ -0,0 +1,107 @@
+package rules
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/mymmrac/battleship/data"
+)
+
+func TestPlaceRandomFleetRepeatable(t *testing.T) {
+	for _, ruleSet := range RuleSets {
+		t.Run(ruleSet.Name, func(t *testing.T) {
+			first, second := NewBoard(ruleSet), NewBoard(ruleSet)
+			if err := first.PlaceRandomFleet(rand.New(rand.NewSource(42))); err != nil {
+				t.Fatal(err)
+			}
+			if err := second.PlaceRandomFleet(rand.New(rand.NewSource(42))); err != nil {
+				t.Fatal(err)
+			}
+
+			if first.String() != second.String() {
+				t.Errorf("fleets placed with the same seed differ:\n%s\n%s", first, second)
+			}
+
+			firstShips, _ := first.FleetShips()
+			secondShips, _ := second.FleetShips()
+			if !reflect.DeepEqual(firstShips, secondShips) {
+				t.Errorf("ships placed with the same seed differ: %v and %v", firstShips, secondShips)
+			}
+		})
+	}
+}
+
+func TestPlaceRandomFleetTouching(t *testing.T) {
+	for _, touching := range Touchings {
+		t.Run(touching.String(), func(t *testing.T) {
+			ruleSet := RuleSetClassic
+			ruleSet.Touching = touching
+
+			rng := rand.New(rand.NewSource(1))
+			for i := 0; i < 100; i++ {
+				board := NewBoard(ruleSet)
+				if err := board.PlaceRandomFleet(rng); err != nil {
+					t.Fatal(err)
+				}
+
+				ships, err := board.FleetShips()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for i := range ships {
+					for j := i + 1; j < len(ships); j++ {
+						if shipsTouch(ships[i], ships[j], touching) {
+							t.Fatalf("ships %v and %v touch\n%s", ships[i], ships[j], board)
+						}
+					}
+				}
+
+				// Placing the same ships again checks them from scratch
+				if err = NewBoard(ruleSet).PlaceFleet(ships); err != nil {
+					t.Fatalf("random fleet breaks the rules: %v\n%s", err, board)
+				}
+
+				if touching != TouchingFree {
+					// Ships that can't touch by sides are found by their cells the same way
+					parsed, err := ParseBoard(ruleSet, board.String())
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					found, err := parsed.FleetShips()
+					if err != nil || len(found) != len(ships) {
+						t.Fatalf("found %d ships, error = %v, want %d ships\n%s", len(found), err, len(ships), board)
+					}
+				}
+			}
+		})
+	}
+}
+
+// shipsTouch reports whether ships overlap or touch each other in a way forbidden by touching rule
+func shipsTouch(a, b []data.Point[int], touching Touching) bool {
+	for _, first := range a {
+		for _, second := range b {
+			dx, dy := abs(first.X-second.X), abs(first.Y-second.Y)
+			switch {
+			case dx == 0 && dy == 0:
+				return true
+			case touching == TouchingNone && dx <= 1 && dy <= 1:
+				return true
+			case touching == TouchingCorners && dx+dy <= 1:
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
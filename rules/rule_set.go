@@ -0,0 +1,255 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+const (
+	MinBoardSize = 6
+	MaxBoardSize = 30
+
+	MaxSalvoShots = 10
+
+	// MaxFleetShips limits fleet size, so that random placement of the fleet stays cheap
+	MaxFleetShips = 30
+)
+
+// CustomRuleSetName is a name of rule set not matching any of predefined ones
+const CustomRuleSetName = "Custom"
+
+// Touching defines how ships may touch each other
+type Touching int
+
+const (
+	// TouchingNone forbids ships to touch each other even by corners
+	TouchingNone Touching = iota
+	// TouchingCorners allows ships to touch each other by corners only
+	TouchingCorners
+	// TouchingFree allows ships to touch each other in any way
+	TouchingFree
+)
+
+// Touchings lists all touching rules, the strictest first
+var Touchings = []Touching{
+	TouchingNone,
+	TouchingCorners,
+	TouchingFree,
+}
+
+func (t Touching) String() string {
+	switch t {
+	case TouchingNone:
+		return "none"
+	case TouchingCorners:
+		return "corners"
+	case TouchingFree:
+		return "free"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseTouching returns touching rule by its name
+func ParseTouching(name string) (Touching, error) {
+	for _, touching := range Touchings {
+		if touching.String() == name {
+			return touching, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown touching rule: %q", name)
+}
+
+// SalvoMode defines how many shots player fires per turn
+type SalvoMode int
+
+const (
+	// SalvoOff is a classic game, player fires one shot at a time and keeps the turn until miss
+	SalvoOff SalvoMode = iota
+	// SalvoFixed gives player a fixed number of shots per turn
+	SalvoFixed
+	// SalvoShips gives player as many shots per turn as many ships player has left
+	SalvoShips
+)
+
+func (m SalvoMode) String() string {
+	switch m {
+	case SalvoOff:
+		return "off"
+	case SalvoFixed:
+		return "fixed"
+	case SalvoShips:
+		return "ships"
+	default:
+		return "unknown"
+	}
+}
+
+// RuleSet describes board and fleet both players use
+type RuleSet struct {
+	Name     string
+	Width    int
+	Height   int
+	Fleet    Fleet
+	Touching Touching
+
+	// Salvo is a salvo mode, SalvoShots is a number of shots per turn for SalvoFixed mode
+	Salvo      SalvoMode
+	SalvoShots int
+}
+
+var (
+	// RuleSetClassic is a classic Russian rule set: 4-3-3-2-2-2-1-1-1-1 on 10x10
+	RuleSetClassic = RuleSet{Name: "Classic", Width: 10, Height: 10, Fleet: Fleet{4, 3, 2, 1}}
+
+	// RuleSetHasbro is Hasbro's rule set: 5-4-3-3-2 on 10x10, ships may touch each other
+	RuleSetHasbro = RuleSet{Name: "Hasbro", Width: 10, Height: 10, Fleet: Fleet{0, 1, 2, 1, 1}, Touching: TouchingFree}
+
+	// RuleSetSmall is a quick game rule set: 3-2-2-1-1 on 7x7
+	RuleSetSmall = RuleSet{Name: "Small", Width: 7, Height: 7, Fleet: Fleet{2, 2, 1}}
+
+	// RuleSetSalvo is Hasbro's rule set where player fires as many shots per turn as many ships player has left
+	RuleSetSalvo = RuleSet{
+		Name:     "Salvo",
+		Width:    10,
+		Height:   10,
+		Fleet:    Fleet{0, 1, 2, 1, 1},
+		Touching: TouchingFree,
+		Salvo:    SalvoShips,
+	}
+)
+
+// DefaultRuleSet is a rule set used if not selected
+var DefaultRuleSet = RuleSetClassic
+
+// RuleSets lists predefined rule sets
+var RuleSets = []RuleSet{
+	RuleSetClassic,
+	RuleSetHasbro,
+	RuleSetSmall,
+	RuleSetSalvo,
+}
+
+// RuleSetByName returns predefined rule set by its name
+func RuleSetByName(name string) (RuleSet, bool) {
+	for _, ruleSet := range RuleSets {
+		if ruleSet.Name == name {
+			return ruleSet, true
+		}
+	}
+
+	return RuleSet{}, false
+}
+
+// RuleSetNames returns names of predefined rule sets
+func RuleSetNames() []string {
+	names := make([]string, 0, len(RuleSets))
+	for _, ruleSet := range RuleSets {
+		names = append(names, ruleSet.Name)
+	}
+
+	return names
+}
+
+// NewCustomRuleSet creates rule set with square board, given fleet, touching rule and salvo mode
+func NewCustomRuleSet(boardSize int, fleet Fleet, touching Touching, salvo SalvoMode, salvoShots int) (
+	RuleSet, error,
+) {
+	ruleSet := RuleSet{
+		Name:       CustomRuleSetName,
+		Width:      boardSize,
+		Height:     boardSize,
+		Fleet:      fleet,
+		Touching:   touching,
+		Salvo:      salvo,
+		SalvoShots: salvoShots,
+	}
+
+	if err := ruleSet.Validate(); err != nil {
+		return RuleSet{}, err
+	}
+
+	return ruleSet, nil
+}
+
+func (r RuleSet) String() string {
+	text := fmt.Sprintf("%s %dx%d %s touching: %s", r.Name, r.Width, r.Height, r.Fleet, r.Touching)
+
+	switch r.Salvo {
+	case SalvoFixed:
+		text += fmt.Sprintf(" salvo: %d", r.SalvoShots)
+	case SalvoShips:
+		text += " salvo: ships"
+	}
+
+	return text
+}
+
+// Shots returns number of shots per turn for player that has alive ships left
+func (r RuleSet) Shots(aliveShips int) int {
+	switch r.Salvo {
+	case SalvoFixed:
+		return r.SalvoShots
+	case SalvoShips:
+		return aliveShips
+	default:
+		return 1
+	}
+}
+
+// Validate checks that board size, touching rule and salvo are allowed and fleet fits on the board
+func (r RuleSet) Validate() error {
+	if r.Width < MinBoardSize || r.Width > MaxBoardSize || r.Height < MinBoardSize || r.Height > MaxBoardSize {
+		return fmt.Errorf("board size %dx%d is not in range from %d to %d",
+			r.Width, r.Height, MinBoardSize, MaxBoardSize)
+	}
+
+	if len(r.Fleet) == 0 || r.Fleet[len(r.Fleet)-1] <= 0 {
+		return errors.New("fleet has no longest ship")
+	}
+
+	if longest := r.Fleet.LongestShip(); longest > r.Width && longest > r.Height {
+		return fmt.Errorf("ship with length %d does not fit on %dx%d board", longest, r.Width, r.Height)
+	}
+
+	ships := 0
+	for i, count := range r.Fleet {
+		if count < 0 {
+			return fmt.Errorf("negative number of ships with length %d", i+1)
+		}
+
+		ships += count
+		if ships > MaxFleetShips {
+			return fmt.Errorf("fleet has more than %d ships", MaxFleetShips)
+		}
+	}
+
+	if cells := r.Fleet.Cells(); cells > r.Width*r.Height {
+		return fmt.Errorf("%w: %d ship cells on %dx%d board", ErrFleetDoesNotFit, cells, r.Width, r.Height)
+	}
+
+	if r.Touching < TouchingNone || r.Touching > TouchingFree {
+		return fmt.Errorf("unknown touching rule: %d", r.Touching)
+	}
+
+	switch r.Salvo {
+	case SalvoOff, SalvoShips:
+		// Valid
+	case SalvoFixed:
+		if r.SalvoShots < 1 || r.SalvoShots > MaxSalvoShots {
+			return fmt.Errorf("salvo shots %d is not in range from 1 to %d", r.SalvoShots, MaxSalvoShots)
+		}
+	default:
+		return fmt.Errorf("unknown salvo mode: %d", r.Salvo)
+	}
+
+	// Fixed seed, so that the same rule set is always either valid or not
+	board := NewBoard(r)
+	if err := board.PlaceRandomFleet(rand.New(rand.NewSource(1))); err != nil {
+		return err
+	}
+
+	return nil
+}
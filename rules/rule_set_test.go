@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRuleSetValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ruleSet RuleSet
+		wantErr bool
+	}{
+		{name: "classic", ruleSet: RuleSetClassic},
+		{name: "salvo", ruleSet: RuleSetSalvo},
+		{name: "board too small", ruleSet: RuleSet{Width: 5, Height: 10, Fleet: Fleet{1}}, wantErr: true},
+		{name: "board too big", ruleSet: RuleSet{Width: 10, Height: 31, Fleet: Fleet{1}}, wantErr: true},
+		{name: "empty fleet", ruleSet: RuleSet{Width: 10, Height: 10}, wantErr: true},
+		{name: "no longest ship", ruleSet: RuleSet{Width: 10, Height: 10, Fleet: Fleet{1, 0}}, wantErr: true},
+		{name: "negative ships", ruleSet: RuleSet{Width: 10, Height: 10, Fleet: Fleet{-1, 1}}, wantErr: true},
+		{
+			name:    "ship longer than board",
+			ruleSet: RuleSet{Width: 6, Height: 8, Fleet: Fleet{0, 0, 0, 0, 0, 0, 0, 0, 1}},
+			wantErr: true,
+		},
+		{
+			name:    "too many ships",
+			ruleSet: RuleSet{Width: 30, Height: 30, Fleet: Fleet{MaxFleetShips + 1}},
+			wantErr: true,
+		},
+		{
+			name:    "huge number of ships",
+			ruleSet: RuleSet{Width: 30, Height: 30, Fleet: Fleet{1 << 30, 1 << 30}},
+			wantErr: true,
+		},
+		{name: "too many cells", ruleSet: RuleSet{Width: 6, Height: 6, Fleet: Fleet{0, 0, 0, 0, 0, 7}}, wantErr: true},
+		{
+			name:    "unknown touching",
+			ruleSet: RuleSet{Width: 10, Height: 10, Fleet: Fleet{1}, Touching: TouchingFree + 1},
+			wantErr: true,
+		},
+		{
+			name:    "salvo shots out of range",
+			ruleSet: RuleSet{Width: 10, Height: 10, Fleet: Fleet{1}, Salvo: SalvoFixed, SalvoShots: MaxSalvoShots + 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ruleSet.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleSetValidateFleetDoesNotFit(t *testing.T) {
+	ruleSet := RuleSet{Width: 6, Height: 6, Fleet: Fleet{0, 0, 0, 0, 0, 7}}
+	if err := ruleSet.Validate(); !errors.Is(err, ErrFleetDoesNotFit) {
+		t.Errorf("Validate() error = %v, want %v", err, ErrFleetDoesNotFit)
+	}
+}
@@ -0,0 +1,133 @@
+// Package rules implements Battleship rules independent of any UI
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Cell int
+
+const (
+	CellEmpty Cell = iota
+	CellShip
+	CellMiss
+	CellShipHit
+)
+
+// Fleet is a number of ships of each length, where Fleet[i] is number of ships with length i+1
+type Fleet []int
+
+// LongestShip returns the maximal allowed ship length
+func (f Fleet) LongestShip() int {
+	return len(f)
+}
+
+// Cells returns total number of cells occupied by all ships
+func (f Fleet) Cells() int {
+	cells := 0
+	for i, count := range f {
+		cells += (i + 1) * count
+	}
+
+	return cells
+}
+
+// Lengths returns lengths of all ships, the longest first
+func (f Fleet) Lengths() []int {
+	var lengths []int
+	for i := len(f) - 1; i >= 0; i-- {
+		for j := 0; j < f[i]; j++ {
+			lengths = append(lengths, i+1)
+		}
+	}
+
+	return lengths
+}
+
+// String returns fleet as ship lengths, for example: 4-3-3-2-2-2-1-1-1-1
+func (f Fleet) String() string {
+	lengths := f.Lengths()
+
+	parts := make([]string, 0, len(lengths))
+	for _, length := range lengths {
+		parts = append(parts, strconv.Itoa(length))
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// ParseFleet parses fleet from ship lengths separated by commas or dashes in any order
+func ParseFleet(text string) (Fleet, error) {
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == '-' || r == ' '
+	})
+	if len(parts) == 0 {
+		return nil, errors.New("empty fleet")
+	}
+
+	lengths := make([]int, 0, len(parts))
+	for _, part := range parts {
+		length, err := strconv.Atoi(part)
+		if err != nil || length <= 0 {
+			return nil, fmt.Errorf("bad ship length: %q", part)
+		}
+		lengths = append(lengths, length)
+	}
+	sort.Ints(lengths)
+
+	fleet := make(Fleet, lengths[len(lengths)-1])
+	for _, length := range lengths {
+		fleet[length-1]++
+	}
+
+	return fleet, nil
+}
+
+// Matches reports whether ship counts are exactly the same as in fleet
+func (f Fleet) Matches(counts []int) bool {
+	if len(counts) != len(f) {
+		return false
+	}
+
+	for i, count := range counts {
+		if f[i] != count {
+			return false
+		}
+	}
+
+	return true
+}
+
+type ShotResult int
+
+const (
+	_ ShotResult = iota
+	ShotMiss
+	ShotHit
+	ShotDestroyed
+)
+
+var (
+	ErrOutOfBounds     = errors.New("out of bounds")
+	ErrAlreadyShot     = errors.New("cell already shot")
+	ErrShipsTouching   = errors.New("ships touching")
+	ErrShipTooLong     = errors.New("ship too long")
+	ErrWrongFleet      = errors.New("wrong fleet")
+	ErrFleetDoesNotFit = errors.New("fleet does not fit")
+	ErrBadShip         = errors.New("bad ship")
+	ErrShipsUnknown    = errors.New("ships that may touch are not known")
+)
+
+// ColumnName returns spreadsheet-style name of zero based column: A, B, ..., Z, AA, AB, ...
+func ColumnName(column int) string {
+	var name []byte
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = append([]byte{byte('A' + (column-1)%26)}, name...)
+	}
+
+	return string(name)
+}
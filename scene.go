@@ -1,17 +1,23 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
+	"math/rand"
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/mymmrac/battleship/ai"
+	"github.com/mymmrac/battleship/client"
 	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/replay"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server"
 	"github.com/mymmrac/battleship/server/api"
 )
 
@@ -22,10 +28,15 @@ const (
 	SceneMenu
 	SceneNewGame
 	SceneJoinGame
+	SceneJoinByCode
 	ScenePlaceShips
 	ScenePlayerReady
 	SceneTheGame
 	SceneTheEnd
+	SceneReplays
+	SceneReplay
+	SceneSpectate
+	SceneSettings
 )
 
 type Scene struct {
@@ -45,6 +56,7 @@ func (g *Game) ChangeScene(id SceneID) {
 	if !ok {
 		panic("unknown scene ID " + strconv.Itoa(int(id)))
 	}
+	g.currentSceneID = id
 
 	enter := g.currentScene.OnEnter
 	if enter != nil {
@@ -57,27 +69,102 @@ func (g *Game) InitScenes() {
 		SceneMenu: {
 			OnEnter: func() {
 				g.newGameBtn.EnableAndShow()
+				g.privateGameBtn.EnableAndShow()
 				g.joinGameBtn.EnableAndShow()
+				g.watchGameBtn.EnableAndShow()
+				g.computerGameBtn.EnableAndShow()
+				g.difficultyBtn.SetText(difficultyText(g.difficulty))
+				g.difficultyBtn.EnableAndShow()
+				g.ruleSetBtn.SetText(ruleSetText(g.ruleSet))
+				g.ruleSetBtn.EnableAndShow()
+				g.replaysBtn.EnableAndShow()
+				g.settingsBtn.EnableAndShow()
 				g.exitBtn.EnableAndShow()
+
+				g.opponentName = "Player"
 			},
 			OnUpdate: func() {
 				if g.newGameBtn.Clicked() {
+					g.newGamePrivate = false
+					g.ChangeScene(SceneNewGame)
+					return
+				}
+
+				if g.privateGameBtn.Clicked() {
+					g.newGamePrivate = true
 					g.ChangeScene(SceneNewGame)
 					return
 				}
 
 				if g.joinGameBtn.Clicked() {
+					g.lobbySpectate = false
 					g.ChangeScene(SceneJoinGame)
 					return
 				}
 
+				if g.watchGameBtn.Clicked() {
+					g.lobbySpectate = true
+					g.ChangeScene(SceneJoinGame)
+					return
+				}
+
+				if g.difficultyBtn.Clicked() {
+					g.difficulty = nextDifficulty(g.difficulty)
+					g.difficultyBtn.SetText(difficultyText(g.difficulty))
+
+					g.settings.Difficulty = g.difficulty.String()
+					g.storeSettings()
+				}
+
+				if g.ruleSetBtn.Clicked() {
+					g.ruleSet = nextRuleSet(g.ruleSets, g.ruleSet)
+					g.ruleSetBtn.SetText(ruleSetText(g.ruleSet))
+
+					g.settings.RuleSet = g.ruleSet
+					g.storeSettings()
+				}
+
+				if g.computerGameBtn.Clicked() {
+					rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+					opponent, err := NewComputerOpponent(ai.NewStrategy(g.difficulty, g.ruleSet, rng), g.ruleSet)
+					if err != nil {
+						fmt.Println(err) // TODO: Fix me
+						return
+					}
+
+					g.setupBoards(g.ruleSet)
+					g.opponent = opponent
+					g.opponentName = "Computer (" + g.difficulty.String() + ")"
+					go g.handleGameEvents()
+
+					g.ChangeScene(ScenePlaceShips)
+					return
+				}
+
+				if g.replaysBtn.Clicked() {
+					g.ChangeScene(SceneReplays)
+					return
+				}
+
+				if g.settingsBtn.Clicked() {
+					g.ChangeScene(SceneSettings)
+					return
+				}
+
 				if g.exitBtn.Clicked() {
 					g.exit = true
 				}
 			},
 			OnLeave: func() {
 				g.newGameBtn.DisableAndHide()
+				g.privateGameBtn.DisableAndHide()
 				g.joinGameBtn.DisableAndHide()
+				g.watchGameBtn.DisableAndHide()
+				g.computerGameBtn.DisableAndHide()
+				g.difficultyBtn.DisableAndHide()
+				g.ruleSetBtn.DisableAndHide()
+				g.replaysBtn.DisableAndHide()
+				g.settingsBtn.DisableAndHide()
 				g.exitBtn.DisableAndHide()
 			},
 		},
@@ -86,38 +173,37 @@ func (g *Game) InitScenes() {
 			OnEnter: func() {
 				g.newGameLoadingLabel.Show()
 				g.newGameLoadingLabel.SetText("Creating new game...")
+				g.setupBoards(g.ruleSet)
 
+				ruleSet := g.ruleSet
 				go func() {
 					var err error
 					// TODO: Close connection
-					g.grpcConn, err = grpc.Dial(g.serverAddr+":"+g.serverPort,
+					g.grpcConn, err = grpc.Dial(serverTarget(g.serverAddr, g.serverPort),
 						grpc.WithTransportCredentials(insecure.NewCredentials()))
 					if err != nil {
 						g.events <- events.NewGameEventError(events.GameEventNewGameStartFailed, err)
 						return
 					}
 
-					client := api.NewEventManagerClient(g.grpcConn)
-					g.eventManager, err = NewEventManagerClient(client)
+					g.eventManager, err = client.NewEventManagerClient(api.NewEventManagerClient(g.grpcConn))
 					if err != nil {
 						g.events <- events.NewGameEventError(events.GameEventNewGameStartFailed, err)
 						return
 					}
 
-					err = g.eventManager.NewGame()
+					var code string
+					code, err = g.eventManager.NewGame(g.playerName, g.newGamePrivate, ruleSet)
 					if err != nil {
 						g.events <- events.NewGameEventError(events.GameEventNewGameStartFailed, err)
 						return
 					}
 
 					time.Sleep(time.Second)
-					g.events <- events.NewGameEventSignal(events.GameEventNewGameStarted)
+					g.opponent = g.eventManager
+					g.events <- events.NewGameEventNewGame(code)
 
-					// TODO: Move to separate place
-					err = g.eventManager.HandleGameEvents(g.events)
-					if err != nil {
-						panic(err)
-					}
+					g.handleGameEvents()
 				}()
 			},
 			OnUpdate: func() {
@@ -129,26 +215,29 @@ func (g *Game) InitScenes() {
 					return
 				}
 
+				if g.handleConnectionEvent(event) {
+					return
+				}
+
 				switch event.EventType() {
 				case events.GameEventNewGameStarted:
 					g.newGameLoadingLabel.SetText("Waiting for other player to join...")
 
+					if code := event.(events.GameEventNewGame).Code; code != "" {
+						g.newGameCodeLabel.SetText("Join code: " + code)
+						g.newGameCodeLabel.Show()
+					}
+
 					// TODO: Make separate scene
 					// g.ChangeScene(sceneWaitForPlayer)
 					// return
 				case events.GameEventFromServer:
-					serverEvent := event.(events.ServerEvent)
-
-					var signalEvent events.GameEventSignal
-					err := json.Unmarshal(serverEvent.Data, &signalEvent)
-					if err != nil {
-						fmt.Println(err) // TODO: Fix me
-						return
-					}
-
-					if signalEvent.Type == events.GameEventJoinedGame {
+					switch payload := event.(events.ServerEvent).Event.GetPayload().(type) {
+					case *api.Event_JoinGame:
 						g.ChangeScene(ScenePlaceShips)
 						return
+					case *api.Event_Error:
+						fmt.Println(payload.Error.GetMessage()) // TODO: Fix me
 					}
 				case events.GameEventNewGameStartFailed:
 					errEvent := event.(events.GameEventError)
@@ -161,50 +250,168 @@ func (g *Game) InitScenes() {
 			},
 			OnLeave: func() {
 				g.newGameLoadingLabel.Hide()
+				g.newGameCodeLabel.Hide()
 			},
 		},
 
 		SceneJoinGame: {
 			OnEnter: func() {
-				go func() {
-					var err error
-					// TODO: Close connection
-					g.grpcConn, err = grpc.Dial(g.serverAddr+":"+g.serverPort,
-						grpc.WithTransportCredentials(insecure.NewCredentials()))
-					if err != nil {
-						g.events <- events.NewGameEventError(events.GameEventJoinGameFailed, err)
-						return
-					}
+				g.lobbyGames = nil
+				g.lobbyJoining = false
+				g.lobbyJoin = make(chan uuid.UUID, 1)
+				g.lobbyStop = make(chan struct{})
+
+				g.lobbyLabel.Show()
+				g.lobbyList.SetItems(nil)
+				g.lobbyList.SetSelected(-1)
+				g.lobbyList.SetEmptyText("Connecting...")
+				g.lobbyList.EnableAndShow()
+				g.lobbyJoinBtn.Show()
+				g.lobbyJoinBtn.Disable()
+				g.lobbyBackBtn.EnableAndShow()
+
+				if g.lobbySpectate {
+					g.lobbyLabel.SetText("Running games")
+					g.lobbyJoinBtn.SetText("Watch")
+					g.lobbyFleetsBtn.SetText(fleetsText(g.lobbyHideFleets))
+					g.lobbyFleetsBtn.EnableAndShow()
+				} else {
+					g.lobbyLabel.SetText("Open games")
+					g.lobbyJoinBtn.SetText("Join")
+					g.lobbyCodeBtn.EnableAndShow()
+				}
 
-					client := api.NewEventManagerClient(g.grpcConn)
-					g.eventManager, err = NewEventManagerClient(client)
-					if err != nil {
-						g.events <- events.NewGameEventError(events.GameEventJoinGameFailed, err)
-						return
-					}
+				go g.runLobby(g.lobbyJoin, g.lobbyStop, g.lobbySpectate)
+			},
+			OnUpdate: func() {
+				if g.lobbyBackBtn.Clicked() {
+					g.ChangeScene(SceneMenu)
+					return
+				}
 
-					games, err := g.eventManager.ListGames()
-					if err != nil {
-						g.events <- events.NewGameEventError(events.GameEventJoinGameFailed, err)
-						return
+				if g.lobbyCodeBtn.Clicked() {
+					g.ChangeScene(SceneJoinByCode)
+					return
+				}
+
+				if g.lobbyFleetsBtn.Clicked() {
+					g.lobbyHideFleets = !g.lobbyHideFleets
+					g.lobbyFleetsBtn.SetText(fleetsText(g.lobbyHideFleets))
+				}
+
+				selected := g.lobbyList.Selected()
+				if !g.lobbyJoining && selected != -1 && (g.lobbyJoinBtn.Clicked() || g.lobbyList.Activated()) {
+					g.lobbyJoining = true
+					g.opponentName = g.lobbyGames[selected].HostName
+					g.lobbyJoin <- g.lobbyGames[selected].ID
+					g.lobbyList.Disable()
+					g.lobbyFleetsBtn.Disable()
+				}
+				g.lobbyJoinBtn.SetActive(!g.lobbyJoining && selected != -1)
+
+				var event events.GameEvent
+				select {
+				case event = <-g.events:
+				// Pass
+				default:
+					return
+				}
+
+				switch event.EventType() {
+				case events.GameEventGameListUpdated:
+					var selectedID uuid.UUID
+					if selected != -1 {
+						selectedID = g.lobbyGames[selected].ID
 					}
 
-					err = g.eventManager.JoinGame(games[0])
-					if err != nil {
-						g.events <- events.NewGameEventError(events.GameEventJoinGameFailed, err)
-						return
+					g.lobbyGames = event.(events.GameEventGameList).Games
+					items := make([]string, 0, len(g.lobbyGames))
+					selected = -1
+					for i, info := range g.lobbyGames {
+						items = append(items, lobbyGameItem(info))
+						if info.ID == selectedID {
+							selected = i
+						}
 					}
 
-					g.events <- events.NewGameEventSignal(events.GameEventJoinedGame)
+					g.lobbyList.SetItems(items)
+					g.lobbyList.SetSelected(selected)
+					if g.lobbySpectate {
+						g.lobbyList.SetEmptyText("No running games, waiting for someone to start one...")
+					} else {
+						g.lobbyList.SetEmptyText("No open games, waiting for someone to host one...")
+					}
+				case events.GameEventFromServer:
+					snapshot := event.(events.ServerEvent).Event.GetSpectatorSnapshot()
+					if snapshot == nil {
+						panic("unexpected server event in lobby")
+					}
 
-					// TODO: Move to separate place
-					err = g.eventManager.HandleGameEvents(g.events)
-					if err != nil {
-						panic(err)
+					if err := g.startSpectating(snapshot); err != nil {
+						fmt.Println(err) // TODO: Fix me
 					}
-				}()
+					g.ChangeScene(SceneSpectate)
+					return
+				case events.GameEventJoinedGame:
+					g.setupBoards(event.(events.GameEventJoined).RuleSet)
+					g.ChangeScene(ScenePlaceShips)
+					return
+				case events.GameEventJoinGameFailed:
+					errEvent := event.(events.GameEventError)
+					fmt.Println(errEvent.Err) // TODO: Fix me
+					g.ChangeScene(SceneMenu)
+					return
+				default:
+					panic("unexpected event type: " + strconv.Itoa(int(event.EventType())))
+				}
+			},
+			OnLeave: func() {
+				close(g.lobbyStop)
+
+				g.lobbyLabel.Hide()
+				g.lobbyList.DisableAndHide()
+				g.lobbyJoinBtn.DisableAndHide()
+				g.lobbyCodeBtn.DisableAndHide()
+				g.lobbyBackBtn.DisableAndHide()
+				g.lobbyFleetsBtn.DisableAndHide()
+			},
+		},
+
+		SceneJoinByCode: {
+			OnEnter: func() {
+				g.joinCodeJoining = false
+
+				g.joinCodeTitleLabel.Show()
+				g.joinCodeInput.Clear()
+				g.joinCodeInput.EnableAndShow()
+				g.joinCodeInput.Focus()
+				g.joinCodeStatusLabel.SetText("")
+				g.joinCodeStatusLabel.Show()
+				g.joinCodeBtn.Show()
+				g.joinCodeBtn.Disable()
+				g.joinCodeBackBtn.EnableAndShow()
 			},
 			OnUpdate: func() {
+				if g.joinCodeBackBtn.Clicked() {
+					g.ChangeScene(SceneJoinGame)
+					return
+				}
+
+				if !g.joinCodeJoining {
+					code := g.joinCodeInput.Text()
+					complete := len(code) == server.JoinCodeLength
+					g.joinCodeBtn.SetActive(complete)
+
+					if complete && (g.joinCodeBtn.Clicked() || g.joinCodeInput.Submitted()) {
+						g.joinCodeJoining = true
+						g.joinCodeBtn.Disable()
+						g.joinCodeInput.Disable()
+						g.joinCodeStatusLabel.SetText("Joining...")
+
+						go g.joinByCode(code)
+					}
+				}
+
 				var event events.GameEvent
 				select {
 				case event = <-g.events:
@@ -215,18 +422,26 @@ func (g *Game) InitScenes() {
 
 				switch event.EventType() {
 				case events.GameEventJoinedGame:
+					g.setupBoards(event.(events.GameEventJoined).RuleSet)
 					g.ChangeScene(ScenePlaceShips)
 					return
 				case events.GameEventJoinGameFailed:
 					errEvent := event.(events.GameEventError)
-					fmt.Println(errEvent.Err) // TODO: Fix me
-					g.ChangeScene(SceneMenu)
-					return
+					g.joinCodeStatusLabel.SetText("Failed to join: " + errEvent.Err.Error())
+					g.joinCodeJoining = false
+					g.joinCodeInput.Enable()
+					g.joinCodeInput.Focus()
 				default:
 					panic("unexpected event type: " + strconv.Itoa(int(event.EventType())))
 				}
 			},
-			OnLeave: nil,
+			OnLeave: func() {
+				g.joinCodeTitleLabel.Hide()
+				g.joinCodeInput.DisableAndHide()
+				g.joinCodeStatusLabel.Hide()
+				g.joinCodeBtn.DisableAndHide()
+				g.joinCodeBackBtn.DisableAndHide()
+			},
 		},
 
 		ScenePlaceShips: {
@@ -234,26 +449,50 @@ func (g *Game) InitScenes() {
 				g.myBoard.EnableAndShow()
 				g.myShipyard.EnableAndShow()
 				g.clearBoardBtn.EnableAndShow()
+				g.autoBoardBtn.EnableAndShow()
 				g.opponentReadyLabel.Show()
 
+				g.presetsLabel.Show()
+				g.presetsList.EnableAndShow()
+				g.presetNameInput.EnableAndShow()
+				g.presetSaveBtn.Show()
+				g.presetLoadBtn.Show()
+				g.presetDeleteBtn.Show()
+				g.presetStatusLabel.SetText("")
+				g.presetStatusLabel.Show()
+				g.refreshPresets()
+
 				g.readyBtn.Disable()
 				g.readyBtn.Show()
+
+				g.showChat()
 			},
 			OnUpdate: func() {
-				if g.myBoard.hover {
+				// Ships are dragged from the shipyard, placed ships can be moved or removed
+				if g.myBoard.hover && !g.myBoard.Dragging() {
 					if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-						g.myBoard.placeShip(g.myBoard.hoverPos)
+						g.myBoard.PickUp(g.myBoard.hoverPos)
 					}
 
 					if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
-						g.myBoard.removeShip(g.myBoard.hoverPos)
+						g.myBoard.model.RemoveShip(g.myBoard.model.ShipAt(g.myBoard.hoverPos))
 					}
 				}
 
 				if g.clearBoardBtn.Clicked() {
-					g.myBoard.cells = [cellsCount][cellsCount]CellKind{}
+					g.myBoard.model.Clear()
 				}
 
+				if g.autoBoardBtn.Clicked() {
+					if err := g.myBoard.model.PlaceRandomFleet(g.rng); err != nil {
+						fmt.Println(err) // TODO: Fix me
+					}
+				}
+
+				g.updatePresets()
+				g.updateClipboard()
+				g.updateChat()
+
 				g.readyBtn.SetActive(g.myShipyard.ready())
 
 				if g.readyBtn.Clicked() {
@@ -269,25 +508,24 @@ func (g *Game) InitScenes() {
 					return
 				}
 
+				if g.handleConnectionEvent(event) {
+					return
+				}
+
 				switch event.EventType() {
 				case events.GameEventFromServer:
-					serverEvent := event.(events.ServerEvent)
-
-					var signalEvent events.GameEventSignal
-					err := json.Unmarshal(serverEvent.Data, &signalEvent)
-					if err != nil {
-						fmt.Println(err) // TODO: Fix me
-						return
-					}
-
-					if signalEvent.Type == events.GameEventPlayerReady {
-						g.opponentReady = true
-						g.opponentReadyLabel.SetText("Opponent: ready")
+					switch payload := event.(events.ServerEvent).Event.GetPayload().(type) {
+					case *api.Event_Ready:
+						g.setOpponentReady(payload.Ready.GetReady())
 						return
-					} else if signalEvent.Type == events.GameEventPlayerNotReady {
-						g.opponentReady = false
-						g.opponentReadyLabel.SetText("Opponent: not ready")
+					case *api.Event_Chat:
+						g.addChatMessage(payload.Chat.GetText(), payload.Chat.GetByOpponent())
+					case *api.Event_OpponentLeft:
+						g.opponentLeft = true
+						g.ChangeScene(SceneTheEnd)
 						return
+					case *api.Event_Error:
+						fmt.Println(payload.Error.GetMessage()) // TODO: Fix me
 					}
 				default:
 					panic("unexpected event type: " + strconv.Itoa(int(event.EventType())))
@@ -298,14 +536,30 @@ func (g *Game) InitScenes() {
 				g.myShipyard.DisableAndHide()
 				g.readyBtn.DisableAndHide()
 				g.clearBoardBtn.DisableAndHide()
+				g.autoBoardBtn.DisableAndHide()
 				g.opponentReadyLabel.Hide()
+
+				g.presetsLabel.Hide()
+				g.presetsList.DisableAndHide()
+				g.presetNameInput.DisableAndHide()
+				g.presetSaveBtn.DisableAndHide()
+				g.presetLoadBtn.DisableAndHide()
+				g.presetDeleteBtn.DisableAndHide()
+				g.presetStatusLabel.Hide()
+
+				g.hideChat()
 			},
 		},
 
 		ScenePlayerReady: {
 			OnEnter: func() {
+				ships, err := g.myBoard.model.FleetShips()
+				if err != nil {
+					fmt.Println(err) // TODO: Fix me
+				}
+
 				go func() {
-					err := g.eventManager.SendGameEvent(events.NewGameEventSignal(events.GameEventPlayerReady))
+					err := g.opponent.Ready(ships)
 					if err != nil {
 						fmt.Println(err) // TODO: Fix me
 						return
@@ -316,18 +570,19 @@ func (g *Game) InitScenes() {
 				g.notReadyBtn.EnableAndShow()
 				g.clearBoardBtn.DisableAndHide()
 				g.opponentReadyLabel.Show()
+
+				g.showChat()
 			},
 			OnUpdate: func() {
-				if g.opponentReady {
-					g.playerTurnLabel.SetText("Opponent's Turn")
-					g.ChangeScene(SceneTheGame)
-					return
-				}
+				// Game starts right after both players are ready
+				g.notReadyBtn.SetActive(!g.opponentReady)
 
 				if g.notReadyBtn.Clicked() {
 					g.ChangeScene(ScenePlaceShips)
 				}
 
+				g.updateChat()
+
 				var event events.GameEvent
 				select {
 				case event = <-g.events:
@@ -336,23 +591,31 @@ func (g *Game) InitScenes() {
 					return
 				}
 
+				if g.handleConnectionEvent(event) {
+					return
+				}
+
 				switch event.EventType() {
 				case events.GameEventFromServer:
-					serverEvent := event.(events.ServerEvent)
-
-					var signalEvent events.GameEventSignal
-					err := json.Unmarshal(serverEvent.Data, &signalEvent)
-					if err != nil {
-						fmt.Println(err) // TODO: Fix me
+					switch payload := event.(events.ServerEvent).Event.GetPayload().(type) {
+					case *api.Event_Ready:
+						g.setOpponentReady(payload.Ready.GetReady())
 						return
-					}
-
-					if signalEvent.Type == events.GameEventPlayerReady {
-						g.opponentReady = true
-						g.myTurn = true
-						g.playerTurnLabel.SetText("Your Turn")
+					case *api.Event_GameStarted:
+						g.setOpponentReady(true)
+						g.shots = int(payload.GameStarted.GetShots())
+						g.setMyTurn(payload.GameStarted.GetMyTurn())
+						g.startRecording()
 						g.ChangeScene(SceneTheGame)
 						return
+					case *api.Event_Chat:
+						g.addChatMessage(payload.Chat.GetText(), payload.Chat.GetByOpponent())
+					case *api.Event_OpponentLeft:
+						g.opponentLeft = true
+						g.ChangeScene(SceneTheEnd)
+						return
+					case *api.Event_Error:
+						fmt.Println(payload.Error.GetMessage()) // TODO: Fix me
 					}
 				default:
 					panic("unexpected event type: " + strconv.Itoa(int(event.EventType())))
@@ -362,13 +625,14 @@ func (g *Game) InitScenes() {
 				g.myBoard.DisableAndHide()
 				g.notReadyBtn.DisableAndHide()
 				g.opponentReadyLabel.Hide()
+				g.hideChat()
 
 				go func() {
-					if g.opponentReady {
+					if g.opponentReady || g.opponentLeft {
 						return
 					}
 
-					err := g.eventManager.SendGameEvent(events.NewGameEventSignal(events.GameEventPlayerNotReady))
+					err := g.opponent.NotReady()
 					if err != nil {
 						fmt.Println(err) // TODO: Fix me
 						return
@@ -382,22 +646,55 @@ func (g *Game) InitScenes() {
 				g.myBoard.Show()
 				g.opponentBoard.EnableAndShow()
 				g.playerTurnLabel.Show()
+				g.shotSent = false
+
+				if g.salvo() {
+					g.fireBtn.Show()
+				}
+
+				g.showChat()
 			},
 			OnUpdate: func() {
 				pos := g.opponentBoard.hoverPos
-				if g.myTurn && g.opponentBoard.hover && g.opponentBoard.canShoot(pos) &&
+				if g.myTurn && g.opponentBoard.hover && g.opponentBoard.model.CanShoot(pos) &&
 					inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-					err := g.eventManager.SendGameEvent(events.NewGameEventCoord(pos))
-					if err != nil {
-						fmt.Println(err) // TODO: Fix me
-						return
+					if g.salvo() {
+						// All targets of the salvo are selected before firing
+						g.opponentBoard.ToggleTarget(pos, g.shots)
+					} else {
+						err := g.opponent.Shoot(pos)
+						if err != nil {
+							fmt.Println(err) // TODO: Fix me
+							return
+						}
+
+						g.myTurn = false
+						g.shotSent = true
+					}
+				}
+
+				if g.salvo() {
+					if g.myTurn && len(g.opponentBoard.Targets()) == g.shots {
+						g.fireBtn.Enable()
+					} else {
+						g.fireBtn.Disable()
 					}
 
-					g.myTurn = false
-					// g.playerTurnLabel.SetText("Opponent's Turn")
-					g.lastShootPos = pos
+					if g.fireBtn.Clicked() {
+						err := g.opponent.Salvo(g.opponentBoard.Targets())
+						if err != nil {
+							fmt.Println(err) // TODO: Fix me
+							return
+						}
+
+						g.opponentBoard.ClearTargets()
+						g.myTurn = false
+						g.shotSent = true
+					}
 				}
 
+				g.updateChat()
+
 				var event events.GameEvent
 				select {
 				case event = <-g.events:
@@ -406,95 +703,103 @@ func (g *Game) InitScenes() {
 					return
 				}
 
+				if g.handleConnectionEvent(event) {
+					return
+				}
+
 				switch event.EventType() {
 				case events.GameEventFromServer:
-					serverEvent := event.(events.ServerEvent)
-
-					var signalEvent events.GameEventSignal
-					if err := json.Unmarshal(serverEvent.Data, &signalEvent); err != nil {
-						fmt.Println(err) // TODO: Fix me
-						return
-					}
-
-					switch signalEvent.EventType() {
-					case events.GameEventShoot:
-						var coordEvent events.GameEventCoord
-						if err := json.Unmarshal(serverEvent.Data, &coordEvent); err != nil {
+					switch payload := event.(events.ServerEvent).Event.GetPayload().(type) {
+					case *api.Event_ShotResult:
+						result, err := events.ShotResultFromGRPC(payload.ShotResult.GetOutcome())
+						if err != nil {
 							fmt.Println(err) // TODO: Fix me
 							return
 						}
 
-						hit := false
-
-						var sendEvent events.GameEvent
-						switch g.myBoard.AtPos(coordEvent.Pos) {
-						case CellEmpty:
-							sendEvent = events.NewGameEventSignal(events.GameEventMiss)
-							g.myBoard.SetAt(coordEvent.Pos, CellMiss)
-						case CellShip:
-							hit = true
-
-							sendEvent = events.NewGameEventSignal(events.GameEventHit)
-							g.myBoard.SetAt(coordEvent.Pos, CellShipHit)
-
-							if g.myBoard.FillIfDestroyed(coordEvent.Pos) {
-								sendEvent = events.NewGameEventSignal(events.GameEventDestroyed)
-							}
+						board := g.opponentBoard
+						if payload.ShotResult.GetByOpponent() {
+							board = g.myBoard
 						}
 
-						go func() {
-							if err := g.eventManager.SendGameEvent(sendEvent); err != nil {
-								fmt.Println(err) // TODO: Fix
+						pos := events.PointFromGRPC(payload.ShotResult.GetPos())
+						board.model.Mark(pos, result)
+						g.recordShot(pos, result, payload.ShotResult.GetByOpponent())
+						g.shotSent = false
+
+						// Turn stays with shooter until miss
+						g.setMyTurn(payload.ShotResult.GetByOpponent() == (result == rules.ShotMiss))
+					case *api.Event_SalvoResult:
+						for _, shotResult := range payload.SalvoResult.GetResults() {
+							result, err := events.ShotResultFromGRPC(shotResult.GetOutcome())
+							if err != nil {
+								fmt.Println(err) // TODO: Fix me
 								return
 							}
-						}()
 
-						g.myTurn = !hit
+							board := g.opponentBoard
+							if shotResult.GetByOpponent() {
+								board = g.myBoard
+							}
+
+							pos := events.PointFromGRPC(shotResult.GetPos())
+							board.model.Mark(pos, result)
+							g.recordShot(pos, result, shotResult.GetByOpponent())
+						}
 
-						if !g.myBoard.HasAlive() {
-							go func() {
-								if err := g.eventManager.SendGameEvent(events.NewGameEventSignal(events.GameEventGameEnded)); err != nil {
-									fmt.Println(err) // TODO: Fix
-									return
-								}
-							}()
+						// Turn goes to opponent after each salvo
+						g.shotSent = false
+						g.shots = int(payload.SalvoResult.GetShots())
+						g.setMyTurn(g.shots > 0)
+					case *api.Event_GameEnded:
+						g.won = payload.GameEnded.GetWon()
 
-							g.ChangeScene(SceneTheEnd)
-							return
+						outcome := replay.OutcomeLost
+						if g.won {
+							outcome = replay.OutcomeWon
 						}
-					case events.GameEventMiss:
-						g.opponentBoard.SetAt(g.lastShootPos, CellMiss)
-					case events.GameEventHit:
-						g.opponentBoard.SetAt(g.lastShootPos, CellShipHit)
-						g.myTurn = true
-					case events.GameEventDestroyed:
-						g.opponentBoard.SetAt(g.lastShootPos, CellShipHit)
-						_ = g.opponentBoard.FillIfDestroyed(g.lastShootPos)
-						g.myTurn = true
-					case events.GameEventGameEnded:
+						g.finishRecording(outcome, events.PointsFromGRPC(payload.GameEnded.GetOpponentShips()))
+
+						g.ChangeScene(SceneTheEnd)
+						return
+					case *api.Event_Chat:
+						g.addChatMessage(payload.Chat.GetText(), payload.Chat.GetByOpponent())
+					case *api.Event_OpponentLeft:
+						g.opponentLeft = true
 						g.ChangeScene(SceneTheEnd)
 						return
+					case *api.Event_Error:
+						fmt.Println(payload.Error.GetMessage()) // TODO: Fix me
+
+						// Rejected shot, turn is still ours
+						if g.shotSent {
+							g.shotSent = false
+							g.setMyTurn(true)
+						}
 					}
 				default:
 					panic("unexpected event type: " + strconv.Itoa(int(event.EventType())))
 				}
-
-				if g.myTurn {
-					g.playerTurnLabel.SetText("Your Turn")
-				} else {
-					g.playerTurnLabel.SetText("Opponent's Turn")
-				}
 			},
 			OnLeave: func() {
 				g.myBoard.DisableAndHide()
 				g.opponentBoard.DisableAndHide()
+				g.opponentBoard.ClearTargets()
 				g.playerTurnLabel.Hide()
+				g.fireBtn.DisableAndHide()
+				g.hideChat()
 			},
 		},
 
 		SceneTheEnd: {
 			OnEnter: func() {
-				if g.myBoard.HasAlive() {
+				if g.disconnected {
+					g.theEndLabel.SetText("Connection Lost!")
+					g.finishRecording(replay.OutcomeDisconnected, nil)
+				} else if g.opponentLeft {
+					g.theEndLabel.SetText("Opponent Left!")
+					g.finishRecording(replay.OutcomeOpponentLeft, nil)
+				} else if g.won {
 					g.theEndLabel.SetText("You Won!")
 				} else {
 					g.theEndLabel.SetText("You Lose!")
@@ -512,6 +817,208 @@ func (g *Game) InitScenes() {
 				g.opponentBoard.Hide()
 			},
 		},
+
+		SceneReplays: {
+			OnEnter: func() {
+				g.replaysLabel.Show()
+				g.replaysList.EnableAndShow()
+				g.replaysPlayBtn.Show()
+				g.replaysBackBtn.EnableAndShow()
+				g.replaysStatusLabel.Show()
+				g.refreshReplays()
+			},
+			OnUpdate: func() {
+				if g.replaysBackBtn.Clicked() {
+					g.ChangeScene(SceneMenu)
+					return
+				}
+
+				selected := g.replaysList.Selected()
+				g.replaysPlayBtn.SetActive(selected >= 0)
+
+				if selected >= 0 && (g.replaysPlayBtn.Clicked() || g.replaysList.Activated()) {
+					if err := g.startPlayback(g.replayPaths[selected]); err != nil {
+						g.replaysStatusLabel.SetText("Failed to load replay: " + err.Error())
+					}
+				}
+			},
+			OnLeave: func() {
+				g.replaysLabel.Hide()
+				g.replaysList.DisableAndHide()
+				g.replaysPlayBtn.DisableAndHide()
+				g.replaysBackBtn.DisableAndHide()
+				g.replaysStatusLabel.Hide()
+			},
+		},
+
+		SceneReplay: {
+			OnEnter: func() {
+				g.myBoard.Show()
+				g.opponentBoard.Show()
+				g.replayLabel.Show()
+				g.replayInfoLabel.Show()
+				g.replayPrevBtn.EnableAndShow()
+				g.replayPauseBtn.EnableAndShow()
+				g.replayNextBtn.EnableAndShow()
+				g.replaySpeedBtn.EnableAndShow()
+				g.replayBackBtn.EnableAndShow()
+				g.updatePlaybackLabels()
+			},
+			OnUpdate: func() {
+				if g.replayBackBtn.Clicked() {
+					if g.replayOnly {
+						g.exit = true
+						return
+					}
+
+					g.ChangeScene(SceneReplays)
+					return
+				}
+
+				g.updatePlayback()
+			},
+			OnLeave: func() {
+				g.myBoard.Hide()
+				g.opponentBoard.Hide()
+				g.replayLabel.Hide()
+				g.replayInfoLabel.Hide()
+				g.replayPrevBtn.DisableAndHide()
+				g.replayPauseBtn.DisableAndHide()
+				g.replayNextBtn.DisableAndHide()
+				g.replaySpeedBtn.DisableAndHide()
+				g.replayBackBtn.DisableAndHide()
+				g.playback = nil
+			},
+		},
+
+		SceneSpectate: {
+			OnEnter: func() {
+				g.myBoard.Show()
+				g.opponentBoard.Show()
+				g.playerTurnLabel.Show()
+				g.spectateInfoLabel.Show()
+				g.spectateLeaveBtn.EnableAndShow()
+			},
+			OnUpdate: func() {
+				if g.spectateLeaveBtn.Clicked() {
+					// Events handler has already stopped if connection was lost
+					if g.disconnected {
+						g.closeConnection()
+						g.ChangeScene(SceneMenu)
+						return
+					}
+
+					g.spectateLeaving = true
+					g.spectateLeaveBtn.Disable()
+					g.stopSpectating()
+				}
+
+				var event events.GameEvent
+				select {
+				case event = <-g.events:
+				// Pass
+				default:
+					return
+				}
+
+				switch event.EventType() {
+				case events.GameEventDisconnected:
+					g.disconnected = true
+					g.closeConnection()
+					if g.spectateLeaving {
+						g.ChangeScene(SceneMenu)
+						return
+					}
+
+					fmt.Println(event.(events.GameEventError).Err) // TODO: Fix me
+					g.playerTurnLabel.SetText("Connection Lost!")
+				case events.GameEventFromServer:
+					switch payload := event.(events.ServerEvent).Event.GetPayload().(type) {
+					case *api.Event_SpectatorSnapshot:
+						if err := g.applySpectatorSnapshot(payload.SpectatorSnapshot); err != nil {
+							fmt.Println(err) // TODO: Fix me
+						}
+					case *api.Event_ShotResult:
+						result, err := g.markSpectatedShot(payload.ShotResult)
+						if err != nil {
+							fmt.Println(err) // TODO: Fix me
+							return
+						}
+
+						// Turn stays with shooter until miss
+						g.setSpectateTurn(payload.ShotResult.GetByOpponent() == (result == rules.ShotMiss))
+					case *api.Event_SalvoResult:
+						for _, shotResult := range payload.SalvoResult.GetResults() {
+							if _, err := g.markSpectatedShot(shotResult); err != nil {
+								fmt.Println(err) // TODO: Fix me
+								return
+							}
+						}
+
+						// Turn goes to other player after each salvo
+						g.setSpectateTurn(!g.spectateHostTurn)
+					case *api.Event_Error:
+						fmt.Println(payload.Error.GetMessage()) // TODO: Fix me
+					}
+				default:
+					panic("unexpected event type: " + strconv.Itoa(int(event.EventType())))
+				}
+			},
+			OnLeave: func() {
+				g.myBoard.Hide()
+				g.opponentBoard.Hide()
+				g.playerTurnLabel.Hide()
+				g.spectateInfoLabel.Hide()
+				g.spectateLeaveBtn.DisableAndHide()
+				g.disconnected = false
+			},
+		},
+
+		SceneSettings: {
+			OnEnter: func() {
+				g.settingsLabel.Show()
+				g.settingsAddrLabel.Show()
+				g.settingsAddrInput.SetText(g.serverAddr)
+				g.settingsAddrInput.EnableAndShow()
+				g.settingsAddrInput.Focus()
+				g.settingsPortLabel.Show()
+				g.settingsPortInput.SetText(g.serverPort)
+				g.settingsPortInput.EnableAndShow()
+				g.settingsNameLabel.Show()
+				g.settingsNameInput.SetText(g.playerName)
+				g.settingsNameInput.EnableAndShow()
+				g.settingsThemeBtn.SetText(themeText(g.settings.theme()))
+				g.settingsThemeBtn.EnableAndShow()
+				g.settingsTestBtn.EnableAndShow()
+				g.settingsSaveBtn.EnableAndShow()
+				g.settingsBackBtn.EnableAndShow()
+				g.settingsStatusLabel.SetText("")
+				g.settingsStatusLabel.Show()
+				g.settingsTest = nil
+			},
+			OnUpdate: func() {
+				if g.settingsBackBtn.Clicked() {
+					g.ChangeScene(SceneMenu)
+					return
+				}
+
+				g.updateSettings()
+			},
+			OnLeave: func() {
+				g.settingsLabel.Hide()
+				g.settingsAddrLabel.Hide()
+				g.settingsAddrInput.DisableAndHide()
+				g.settingsPortLabel.Hide()
+				g.settingsPortInput.DisableAndHide()
+				g.settingsNameLabel.Hide()
+				g.settingsNameInput.DisableAndHide()
+				g.settingsThemeBtn.DisableAndHide()
+				g.settingsTestBtn.DisableAndHide()
+				g.settingsSaveBtn.DisableAndHide()
+				g.settingsBackBtn.DisableAndHide()
+				g.settingsStatusLabel.Hide()
+			},
+		},
 	}
 
 	g.scenes = scenes
@@ -9,6 +9,7 @@ package api
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,14 +21,291 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Touching defines how ships may touch each other
+type Touching int32
+
+const (
+	Touching_TOUCHING_NONE    Touching = 0
+	Touching_TOUCHING_CORNERS Touching = 1
+	Touching_TOUCHING_FREE    Touching = 2
+)
+
+// Enum value maps for Touching.
+var (
+	Touching_name = map[int32]string{
+		0: "TOUCHING_NONE",
+		1: "TOUCHING_CORNERS",
+		2: "TOUCHING_FREE",
+	}
+	Touching_value = map[string]int32{
+		"TOUCHING_NONE":    0,
+		"TOUCHING_CORNERS": 1,
+		"TOUCHING_FREE":    2,
+	}
+)
+
+func (x Touching) Enum() *Touching {
+	p := new(Touching)
+	*p = x
+	return p
+}
+
+func (x Touching) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Touching) Descriptor() protoreflect.EnumDescriptor {
+	return file_event_manager_proto_enumTypes[0].Descriptor()
+}
+
+func (Touching) Type() protoreflect.EnumType {
+	return &file_event_manager_proto_enumTypes[0]
+}
+
+func (x Touching) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Touching.Descriptor instead.
+func (Touching) EnumDescriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{0}
+}
+
+// SalvoMode defines how many shots player fires per turn
+type SalvoMode int32
+
+const (
+	SalvoMode_SALVO_MODE_OFF   SalvoMode = 0
+	SalvoMode_SALVO_MODE_FIXED SalvoMode = 1
+	SalvoMode_SALVO_MODE_SHIPS SalvoMode = 2
+)
+
+// Enum value maps for SalvoMode.
+var (
+	SalvoMode_name = map[int32]string{
+		0: "SALVO_MODE_OFF",
+		1: "SALVO_MODE_FIXED",
+		2: "SALVO_MODE_SHIPS",
+	}
+	SalvoMode_value = map[string]int32{
+		"SALVO_MODE_OFF":   0,
+		"SALVO_MODE_FIXED": 1,
+		"SALVO_MODE_SHIPS": 2,
+	}
+)
+
+func (x SalvoMode) Enum() *SalvoMode {
+	p := new(SalvoMode)
+	*p = x
+	return p
+}
+
+func (x SalvoMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SalvoMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_event_manager_proto_enumTypes[1].Descriptor()
+}
+
+func (SalvoMode) Type() protoreflect.EnumType {
+	return &file_event_manager_proto_enumTypes[1]
+}
+
+func (x SalvoMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SalvoMode.Descriptor instead.
+func (SalvoMode) EnumDescriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{1}
+}
+
+type ShotOutcome int32
+
+const (
+	ShotOutcome_SHOT_OUTCOME_UNSPECIFIED ShotOutcome = 0
+	ShotOutcome_SHOT_OUTCOME_MISS        ShotOutcome = 1
+	ShotOutcome_SHOT_OUTCOME_HIT         ShotOutcome = 2
+	ShotOutcome_SHOT_OUTCOME_DESTROYED   ShotOutcome = 3
+)
+
+// Enum value maps for ShotOutcome.
+var (
+	ShotOutcome_name = map[int32]string{
+		0: "SHOT_OUTCOME_UNSPECIFIED",
+		1: "SHOT_OUTCOME_MISS",
+		2: "SHOT_OUTCOME_HIT",
+		3: "SHOT_OUTCOME_DESTROYED",
+	}
+	ShotOutcome_value = map[string]int32{
+		"SHOT_OUTCOME_UNSPECIFIED": 0,
+		"SHOT_OUTCOME_MISS":        1,
+		"SHOT_OUTCOME_HIT":         2,
+		"SHOT_OUTCOME_DESTROYED":   3,
+	}
+)
+
+func (x ShotOutcome) Enum() *ShotOutcome {
+	p := new(ShotOutcome)
+	*p = x
+	return p
+}
+
+func (x ShotOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShotOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_event_manager_proto_enumTypes[2].Descriptor()
+}
+
+func (ShotOutcome) Type() protoreflect.EnumType {
+	return &file_event_manager_proto_enumTypes[2]
+}
+
+func (x ShotOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShotOutcome.Descriptor instead.
+func (ShotOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{2}
+}
+
+type GameState int32
+
+const (
+	GameState_GAME_STATE_UNSPECIFIED GameState = 0
+	GameState_GAME_STATE_WAITING     GameState = 1
+	GameState_GAME_STATE_PLACING     GameState = 2
+	GameState_GAME_STATE_PLAYING     GameState = 3
+	GameState_GAME_STATE_FINISHED    GameState = 4
+)
+
+// Enum value maps for GameState.
+var (
+	GameState_name = map[int32]string{
+		0: "GAME_STATE_UNSPECIFIED",
+		1: "GAME_STATE_WAITING",
+		2: "GAME_STATE_PLACING",
+		3: "GAME_STATE_PLAYING",
+		4: "GAME_STATE_FINISHED",
+	}
+	GameState_value = map[string]int32{
+		"GAME_STATE_UNSPECIFIED": 0,
+		"GAME_STATE_WAITING":     1,
+		"GAME_STATE_PLACING":     2,
+		"GAME_STATE_PLAYING":     3,
+		"GAME_STATE_FINISHED":    4,
+	}
+)
+
+func (x GameState) Enum() *GameState {
+	p := new(GameState)
+	*p = x
+	return p
+}
+
+func (x GameState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameState) Descriptor() protoreflect.EnumDescriptor {
+	return file_event_manager_proto_enumTypes[3].Descriptor()
+}
+
+func (GameState) Type() protoreflect.EnumType {
+	return &file_event_manager_proto_enumTypes[3]
+}
+
+func (x GameState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameState.Descriptor instead.
+func (GameState) EnumDescriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{3}
+}
+
+type Cell int32
+
+const (
+	Cell_CELL_EMPTY    Cell = 0
+	Cell_CELL_SHIP     Cell = 1
+	Cell_CELL_MISS     Cell = 2
+	Cell_CELL_SHIP_HIT Cell = 3
+)
+
+// Enum value maps for Cell.
+var (
+	Cell_name = map[int32]string{
+		0: "CELL_EMPTY",
+		1: "CELL_SHIP",
+		2: "CELL_MISS",
+		3: "CELL_SHIP_HIT",
+	}
+	Cell_value = map[string]int32{
+		"CELL_EMPTY":    0,
+		"CELL_SHIP":     1,
+		"CELL_MISS":     2,
+		"CELL_SHIP_HIT": 3,
+	}
+)
+
+func (x Cell) Enum() *Cell {
+	p := new(Cell)
+	*p = x
+	return p
+}
+
+func (x Cell) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Cell) Descriptor() protoreflect.EnumDescriptor {
+	return file_event_manager_proto_enumTypes[4].Descriptor()
+}
+
+func (Cell) Type() protoreflect.EnumType {
+	return &file_event_manager_proto_enumTypes[4]
+}
+
+func (x Cell) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Cell.Descriptor instead.
+func (Cell) EnumDescriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{4}
+}
+
 type Event struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type int32  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
-	From *UUID  `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
-	Data []byte `protobuf:"bytes,3,opt,name=data,proto3,oneof" json:"data,omitempty"`
+	From *UUID `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// Types that are assignable to Payload:
+	//	*Event_NewGame
+	//	*Event_ListGames
+	//	*Event_JoinGame
+	//	*Event_Ready
+	//	*Event_Shoot
+	//	*Event_ShotResult
+	//	*Event_GameEnded
+	//	*Event_Error
+	//	*Event_GameCreated
+	//	*Event_OpponentLeft
+	//	*Event_Resume
+	//	*Event_Snapshot
+	//	*Event_GameStarted
+	//	*Event_Salvo
+	//	*Event_SalvoResult
+	//	*Event_Spectate
+	//	*Event_SpectatorSnapshot
+	//	*Event_Chat
+	Payload isEvent_Payload `protobuf_oneof:"payload"`
 }
 
 func (x *Event) Reset() {
@@ -62,27 +340,258 @@ func (*Event) Descriptor() ([]byte, []int) {
 	return file_event_manager_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *Event) GetType() int32 {
+func (x *Event) GetFrom() *UUID {
 	if x != nil {
-		return x.Type
+		return x.From
 	}
-	return 0
+	return nil
 }
 
-func (x *Event) GetFrom() *UUID {
-	if x != nil {
-		return x.From
+func (m *Event) GetPayload() isEvent_Payload {
+	if m != nil {
+		return m.Payload
 	}
 	return nil
 }
 
-func (x *Event) GetData() []byte {
-	if x != nil {
-		return x.Data
+func (x *Event) GetNewGame() *NewGame {
+	if x, ok := x.GetPayload().(*Event_NewGame); ok {
+		return x.NewGame
+	}
+	return nil
+}
+
+func (x *Event) GetListGames() *ListGames {
+	if x, ok := x.GetPayload().(*Event_ListGames); ok {
+		return x.ListGames
+	}
+	return nil
+}
+
+func (x *Event) GetJoinGame() *JoinGame {
+	if x, ok := x.GetPayload().(*Event_JoinGame); ok {
+		return x.JoinGame
+	}
+	return nil
+}
+
+func (x *Event) GetReady() *Ready {
+	if x, ok := x.GetPayload().(*Event_Ready); ok {
+		return x.Ready
+	}
+	return nil
+}
+
+func (x *Event) GetShoot() *Shoot {
+	if x, ok := x.GetPayload().(*Event_Shoot); ok {
+		return x.Shoot
+	}
+	return nil
+}
+
+func (x *Event) GetShotResult() *ShotResult {
+	if x, ok := x.GetPayload().(*Event_ShotResult); ok {
+		return x.ShotResult
+	}
+	return nil
+}
+
+func (x *Event) GetGameEnded() *GameEnded {
+	if x, ok := x.GetPayload().(*Event_GameEnded); ok {
+		return x.GameEnded
+	}
+	return nil
+}
+
+func (x *Event) GetError() *Error {
+	if x, ok := x.GetPayload().(*Event_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *Event) GetGameCreated() *GameCreated {
+	if x, ok := x.GetPayload().(*Event_GameCreated); ok {
+		return x.GameCreated
+	}
+	return nil
+}
+
+func (x *Event) GetOpponentLeft() *OpponentLeft {
+	if x, ok := x.GetPayload().(*Event_OpponentLeft); ok {
+		return x.OpponentLeft
+	}
+	return nil
+}
+
+func (x *Event) GetResume() *Resume {
+	if x, ok := x.GetPayload().(*Event_Resume); ok {
+		return x.Resume
+	}
+	return nil
+}
+
+func (x *Event) GetSnapshot() *Snapshot {
+	if x, ok := x.GetPayload().(*Event_Snapshot); ok {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *Event) GetGameStarted() *GameStarted {
+	if x, ok := x.GetPayload().(*Event_GameStarted); ok {
+		return x.GameStarted
+	}
+	return nil
+}
+
+func (x *Event) GetSalvo() *Salvo {
+	if x, ok := x.GetPayload().(*Event_Salvo); ok {
+		return x.Salvo
+	}
+	return nil
+}
+
+func (x *Event) GetSalvoResult() *SalvoResult {
+	if x, ok := x.GetPayload().(*Event_SalvoResult); ok {
+		return x.SalvoResult
+	}
+	return nil
+}
+
+func (x *Event) GetSpectate() *Spectate {
+	if x, ok := x.GetPayload().(*Event_Spectate); ok {
+		return x.Spectate
+	}
+	return nil
+}
+
+func (x *Event) GetSpectatorSnapshot() *SpectatorSnapshot {
+	if x, ok := x.GetPayload().(*Event_SpectatorSnapshot); ok {
+		return x.SpectatorSnapshot
+	}
+	return nil
+}
+
+func (x *Event) GetChat() *Chat {
+	if x, ok := x.GetPayload().(*Event_Chat); ok {
+		return x.Chat
 	}
 	return nil
 }
 
+type isEvent_Payload interface {
+	isEvent_Payload()
+}
+
+type Event_NewGame struct {
+	NewGame *NewGame `protobuf:"bytes,10,opt,name=new_game,json=newGame,proto3,oneof"`
+}
+
+type Event_ListGames struct {
+	ListGames *ListGames `protobuf:"bytes,11,opt,name=list_games,json=listGames,proto3,oneof"`
+}
+
+type Event_JoinGame struct {
+	JoinGame *JoinGame `protobuf:"bytes,12,opt,name=join_game,json=joinGame,proto3,oneof"`
+}
+
+type Event_Ready struct {
+	Ready *Ready `protobuf:"bytes,13,opt,name=ready,proto3,oneof"`
+}
+
+type Event_Shoot struct {
+	Shoot *Shoot `protobuf:"bytes,14,opt,name=shoot,proto3,oneof"`
+}
+
+type Event_ShotResult struct {
+	ShotResult *ShotResult `protobuf:"bytes,15,opt,name=shot_result,json=shotResult,proto3,oneof"`
+}
+
+type Event_GameEnded struct {
+	GameEnded *GameEnded `protobuf:"bytes,16,opt,name=game_ended,json=gameEnded,proto3,oneof"`
+}
+
+type Event_Error struct {
+	Error *Error `protobuf:"bytes,17,opt,name=error,proto3,oneof"`
+}
+
+type Event_GameCreated struct {
+	GameCreated *GameCreated `protobuf:"bytes,18,opt,name=game_created,json=gameCreated,proto3,oneof"`
+}
+
+type Event_OpponentLeft struct {
+	OpponentLeft *OpponentLeft `protobuf:"bytes,19,opt,name=opponent_left,json=opponentLeft,proto3,oneof"`
+}
+
+type Event_Resume struct {
+	Resume *Resume `protobuf:"bytes,20,opt,name=resume,proto3,oneof"`
+}
+
+type Event_Snapshot struct {
+	Snapshot *Snapshot `protobuf:"bytes,21,opt,name=snapshot,proto3,oneof"`
+}
+
+type Event_GameStarted struct {
+	GameStarted *GameStarted `protobuf:"bytes,22,opt,name=game_started,json=gameStarted,proto3,oneof"`
+}
+
+type Event_Salvo struct {
+	Salvo *Salvo `protobuf:"bytes,23,opt,name=salvo,proto3,oneof"`
+}
+
+type Event_SalvoResult struct {
+	SalvoResult *SalvoResult `protobuf:"bytes,24,opt,name=salvo_result,json=salvoResult,proto3,oneof"`
+}
+
+type Event_Spectate struct {
+	Spectate *Spectate `protobuf:"bytes,25,opt,name=spectate,proto3,oneof"`
+}
+
+type Event_SpectatorSnapshot struct {
+	SpectatorSnapshot *SpectatorSnapshot `protobuf:"bytes,26,opt,name=spectator_snapshot,json=spectatorSnapshot,proto3,oneof"`
+}
+
+type Event_Chat struct {
+	Chat *Chat `protobuf:"bytes,27,opt,name=chat,proto3,oneof"`
+}
+
+func (*Event_NewGame) isEvent_Payload() {}
+
+func (*Event_ListGames) isEvent_Payload() {}
+
+func (*Event_JoinGame) isEvent_Payload() {}
+
+func (*Event_Ready) isEvent_Payload() {}
+
+func (*Event_Shoot) isEvent_Payload() {}
+
+func (*Event_ShotResult) isEvent_Payload() {}
+
+func (*Event_GameEnded) isEvent_Payload() {}
+
+func (*Event_Error) isEvent_Payload() {}
+
+func (*Event_GameCreated) isEvent_Payload() {}
+
+func (*Event_OpponentLeft) isEvent_Payload() {}
+
+func (*Event_Resume) isEvent_Payload() {}
+
+func (*Event_Snapshot) isEvent_Payload() {}
+
+func (*Event_GameStarted) isEvent_Payload() {}
+
+func (*Event_Salvo) isEvent_Payload() {}
+
+func (*Event_SalvoResult) isEvent_Payload() {}
+
+func (*Event_Spectate) isEvent_Payload() {}
+
+func (*Event_SpectatorSnapshot) isEvent_Payload() {}
+
+func (*Event_Chat) isEvent_Payload() {}
+
 type UUID struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -130,51 +639,1784 @@ func (x *UUID) GetValue() []byte {
 	return nil
 }
 
-var File_event_manager_proto protoreflect.FileDescriptor
+type Point struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_event_manager_proto_rawDesc = []byte{
-	0x0a, 0x13, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x61, 0x70, 0x69, 0x22, 0x5c, 0x0a, 0x05, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x55, 0x49, 0x44,
-	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x17, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x88, 0x01, 0x01, 0x42,
-	0x07, 0x0a, 0x05, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x22, 0x1c, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x32, 0x36, 0x0a, 0x0c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x06, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
-	0x12, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x0a, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x07,
-	0x5a, 0x05, 0x2e, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	X int32 `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y int32 `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
 }
 
-var (
-	file_event_manager_proto_rawDescOnce sync.Once
-	file_event_manager_proto_rawDescData = file_event_manager_proto_rawDesc
-)
+func (x *Point) Reset() {
+	*x = Point{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_event_manager_proto_rawDescGZIP() []byte {
-	file_event_manager_proto_rawDescOnce.Do(func() {
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Point) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// Ship is cells of one ship
+type Ship struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cells []*Point `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+}
+
+func (x *Ship) Reset() {
+	*x = Ship{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ship) ProtoMessage() {}
+
+func (x *Ship) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ship.ProtoReflect.Descriptor instead.
+func (*Ship) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Ship) GetCells() []*Point {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+// RuleSet describes board size, fleet, touching rule and salvo mode, fleet[i] is a number of ships with length i+1,
+// salvo_shots is used only by fixed salvo mode
+type RuleSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Width      int32     `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height     int32     `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Fleet      []int32   `protobuf:"varint,4,rep,packed,name=fleet,proto3" json:"fleet,omitempty"`
+	Touching   Touching  `protobuf:"varint,5,opt,name=touching,proto3,enum=api.Touching" json:"touching,omitempty"`
+	Salvo      SalvoMode `protobuf:"varint,6,opt,name=salvo,proto3,enum=api.SalvoMode" json:"salvo,omitempty"`
+	SalvoShots int32     `protobuf:"varint,7,opt,name=salvo_shots,json=salvoShots,proto3" json:"salvo_shots,omitempty"`
+}
+
+func (x *RuleSet) Reset() {
+	*x = RuleSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RuleSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleSet) ProtoMessage() {}
+
+func (x *RuleSet) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleSet.ProtoReflect.Descriptor instead.
+func (*RuleSet) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RuleSet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RuleSet) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RuleSet) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RuleSet) GetFleet() []int32 {
+	if x != nil {
+		return x.Fleet
+	}
+	return nil
+}
+
+func (x *RuleSet) GetTouching() Touching {
+	if x != nil {
+		return x.Touching
+	}
+	return Touching_TOUCHING_NONE
+}
+
+func (x *RuleSet) GetSalvo() SalvoMode {
+	if x != nil {
+		return x.Salvo
+	}
+	return SalvoMode_SALVO_MODE_OFF
+}
+
+func (x *RuleSet) GetSalvoShots() int32 {
+	if x != nil {
+		return x.SalvoShots
+	}
+	return 0
+}
+
+// NewGame is sent by client to host a new game with chosen rule set, private games are not listed and can be joined
+// only by code
+type NewGame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HostName string   `protobuf:"bytes,1,opt,name=host_name,json=hostName,proto3" json:"host_name,omitempty"`
+	Private  bool     `protobuf:"varint,2,opt,name=private,proto3" json:"private,omitempty"`
+	Rules    *RuleSet `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *NewGame) Reset() {
+	*x = NewGame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewGame) ProtoMessage() {}
+
+func (x *NewGame) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewGame.ProtoReflect.Descriptor instead.
+func (*NewGame) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NewGame) GetHostName() string {
+	if x != nil {
+		return x.HostName
+	}
+	return ""
+}
+
+func (x *NewGame) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *NewGame) GetRules() *RuleSet {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// GameCreated is sent by server to the host in response to NewGame, code is set only for private games, session
+// token is used to resume the game after reconnect
+type GameCreated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId       *UUID  `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Code         string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	SessionToken string `protobuf:"bytes,3,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+}
+
+func (x *GameCreated) Reset() {
+	*x = GameCreated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameCreated) ProtoMessage() {}
+
+func (x *GameCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameCreated.ProtoReflect.Descriptor instead.
+func (*GameCreated) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GameCreated) GetGameId() *UUID {
+	if x != nil {
+		return x.GameId
+	}
+	return nil
+}
+
+func (x *GameCreated) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GameCreated) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+// GameInfo describes game that is open for joining
+type GameInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	HostName  string                 `protobuf:"bytes,2,opt,name=host_name,json=hostName,proto3" json:"host_name,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	RuleSet   string                 `protobuf:"bytes,4,opt,name=rule_set,json=ruleSet,proto3" json:"rule_set,omitempty"`
+}
+
+func (x *GameInfo) Reset() {
+	*x = GameInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameInfo) ProtoMessage() {}
+
+func (x *GameInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameInfo.ProtoReflect.Descriptor instead.
+func (*GameInfo) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GameInfo) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *GameInfo) GetHostName() string {
+	if x != nil {
+		return x.HostName
+	}
+	return ""
+}
+
+func (x *GameInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GameInfo) GetRuleSet() string {
+	if x != nil {
+		return x.RuleSet
+	}
+	return ""
+}
+
+// ListGames is sent by client to request open games or, if running is set, public games that are being played, server
+// responds with the same message containing games
+type ListGames struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Games   []*GameInfo `protobuf:"bytes,2,rep,name=games,proto3" json:"games,omitempty"`
+	Running bool        `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+}
+
+func (x *ListGames) Reset() {
+	*x = ListGames{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGames) ProtoMessage() {}
+
+func (x *ListGames) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGames.ProtoReflect.Descriptor instead.
+func (*ListGames) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListGames) GetGames() []*GameInfo {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+func (x *ListGames) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+// JoinGame is sent by client to join a game by ID or by code, server sends it back to confirm join (with rule set of
+// the game and session token used to resume the game after reconnect) and to the host when opponent joins
+type JoinGame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId       *UUID    `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Code         string   `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	SessionToken string   `protobuf:"bytes,3,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	Rules        *RuleSet `protobuf:"bytes,4,opt,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *JoinGame) Reset() {
+	*x = JoinGame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGame) ProtoMessage() {}
+
+func (x *JoinGame) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGame.ProtoReflect.Descriptor instead.
+func (*JoinGame) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JoinGame) GetGameId() *UUID {
+	if x != nil {
+		return x.GameId
+	}
+	return nil
+}
+
+func (x *JoinGame) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *JoinGame) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+func (x *JoinGame) GetRules() *RuleSet {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// Ready is sent by client when its fleet is placed or changed back to not ready, server forwards it to the opponent
+// without ships
+type Ready struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ready bool    `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	Ships []*Ship `protobuf:"bytes,3,rep,name=ships,proto3" json:"ships,omitempty"`
+}
+
+func (x *Ready) Reset() {
+	*x = Ready{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ready) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ready) ProtoMessage() {}
+
+func (x *Ready) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ready.ProtoReflect.Descriptor instead.
+func (*Ready) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Ready) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *Ready) GetShips() []*Ship {
+	if x != nil {
+		return x.Ships
+	}
+	return nil
+}
+
+// Shoot is sent by client whose turn it is
+type Shoot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pos *Point `protobuf:"bytes,1,opt,name=pos,proto3" json:"pos,omitempty"`
+}
+
+func (x *Shoot) Reset() {
+	*x = Shoot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Shoot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shoot) ProtoMessage() {}
+
+func (x *Shoot) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shoot.ProtoReflect.Descriptor instead.
+func (*Shoot) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Shoot) GetPos() *Point {
+	if x != nil {
+		return x.Pos
+	}
+	return nil
+}
+
+// Salvo is sent instead of Shoot in salvo games, it contains all shots of the turn
+type Salvo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Targets []*Point `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+}
+
+func (x *Salvo) Reset() {
+	*x = Salvo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Salvo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Salvo) ProtoMessage() {}
+
+func (x *Salvo) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Salvo.ProtoReflect.Descriptor instead.
+func (*Salvo) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Salvo) GetTargets() []*Point {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+// ShotResult is sent by server to both players after each shot
+type ShotResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pos        *Point      `protobuf:"bytes,1,opt,name=pos,proto3" json:"pos,omitempty"`
+	Outcome    ShotOutcome `protobuf:"varint,2,opt,name=outcome,proto3,enum=api.ShotOutcome" json:"outcome,omitempty"`
+	ByOpponent bool        `protobuf:"varint,3,opt,name=by_opponent,json=byOpponent,proto3" json:"by_opponent,omitempty"`
+}
+
+func (x *ShotResult) Reset() {
+	*x = ShotResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShotResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShotResult) ProtoMessage() {}
+
+func (x *ShotResult) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShotResult.ProtoReflect.Descriptor instead.
+func (*ShotResult) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ShotResult) GetPos() *Point {
+	if x != nil {
+		return x.Pos
+	}
+	return nil
+}
+
+func (x *ShotResult) GetOutcome() ShotOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return ShotOutcome_SHOT_OUTCOME_UNSPECIFIED
+}
+
+func (x *ShotResult) GetByOpponent() bool {
+	if x != nil {
+		return x.ByOpponent
+	}
+	return false
+}
+
+// SalvoResult is sent by server to both players after each salvo, shots is a number of shots receiver fires in the
+// next turn or zero if the next turn is opponent's
+type SalvoResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*ShotResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Shots   int32         `protobuf:"varint,2,opt,name=shots,proto3" json:"shots,omitempty"`
+}
+
+func (x *SalvoResult) Reset() {
+	*x = SalvoResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SalvoResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SalvoResult) ProtoMessage() {}
+
+func (x *SalvoResult) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SalvoResult.ProtoReflect.Descriptor instead.
+func (*SalvoResult) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SalvoResult) GetResults() []*ShotResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SalvoResult) GetShots() int32 {
+	if x != nil {
+		return x.Shots
+	}
+	return 0
+}
+
+// GameEnded is sent by server to both players when one of fleets is destroyed, opponent's fleet is revealed
+type GameEnded struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Won           bool     `protobuf:"varint,1,opt,name=won,proto3" json:"won,omitempty"`
+	OpponentShips []*Point `protobuf:"bytes,2,rep,name=opponent_ships,json=opponentShips,proto3" json:"opponent_ships,omitempty"`
+}
+
+func (x *GameEnded) Reset() {
+	*x = GameEnded{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameEnded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameEnded) ProtoMessage() {}
+
+func (x *GameEnded) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameEnded.ProtoReflect.Descriptor instead.
+func (*GameEnded) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GameEnded) GetWon() bool {
+	if x != nil {
+		return x.Won
+	}
+	return false
+}
+
+func (x *GameEnded) GetOpponentShips() []*Point {
+	if x != nil {
+		return x.OpponentShips
+	}
+	return nil
+}
+
+// GameStarted is sent by server to both players when both are ready, player who got ready first shoots first, shots
+// is a number of shots receiver fires in the first turn or zero if it's opponent's turn
+type GameStarted struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MyTurn bool  `protobuf:"varint,1,opt,name=my_turn,json=myTurn,proto3" json:"my_turn,omitempty"`
+	Shots  int32 `protobuf:"varint,2,opt,name=shots,proto3" json:"shots,omitempty"`
+}
+
+func (x *GameStarted) Reset() {
+	*x = GameStarted{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameStarted) ProtoMessage() {}
+
+func (x *GameStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameStarted.ProtoReflect.Descriptor instead.
+func (*GameStarted) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GameStarted) GetMyTurn() bool {
+	if x != nil {
+		return x.MyTurn
+	}
+	return false
+}
+
+func (x *GameStarted) GetShots() int32 {
+	if x != nil {
+		return x.Shots
+	}
+	return 0
+}
+
+// Chat is sent by client to the opponent, server relays it to both players, by_opponent is set for the receiver of
+// the opponent's message, messages are limited in length and rate
+type Chat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text       string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	ByOpponent bool   `protobuf:"varint,2,opt,name=by_opponent,json=byOpponent,proto3" json:"by_opponent,omitempty"`
+}
+
+func (x *Chat) Reset() {
+	*x = Chat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Chat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chat) ProtoMessage() {}
+
+func (x *Chat) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
+func (*Chat) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Chat) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Chat) GetByOpponent() bool {
+	if x != nil {
+		return x.ByOpponent
+	}
+	return false
+}
+
+// Resume is sent by client after reconnect to take its seat back, server responds with Snapshot
+type Resume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionToken string `protobuf:"bytes,1,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+}
+
+func (x *Resume) Reset() {
+	*x = Resume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Resume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resume) ProtoMessage() {}
+
+func (x *Resume) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resume.ProtoReflect.Descriptor instead.
+func (*Resume) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Resume) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+// Board contains cells row by row
+type Board struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Width  int32  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Cells  []Cell `protobuf:"varint,3,rep,packed,name=cells,proto3,enum=api.Cell" json:"cells,omitempty"`
+}
+
+func (x *Board) Reset() {
+	*x = Board{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Board) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Board) ProtoMessage() {}
+
+func (x *Board) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Board.ProtoReflect.Descriptor instead.
+func (*Board) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Board) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Board) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Board) GetCells() []Cell {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+// Snapshot is a full state of the game from player's point of view, opponent's ships that were not hit are hidden,
+// shots is a number of shots player fires in the current turn
+type Snapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State         GameState `protobuf:"varint,1,opt,name=state,proto3,enum=api.GameState" json:"state,omitempty"`
+	Board         *Board    `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`
+	OpponentBoard *Board    `protobuf:"bytes,3,opt,name=opponent_board,json=opponentBoard,proto3" json:"opponent_board,omitempty"`
+	Ready         bool      `protobuf:"varint,4,opt,name=ready,proto3" json:"ready,omitempty"`
+	OpponentReady bool      `protobuf:"varint,5,opt,name=opponent_ready,json=opponentReady,proto3" json:"opponent_ready,omitempty"`
+	MyTurn        bool      `protobuf:"varint,6,opt,name=my_turn,json=myTurn,proto3" json:"my_turn,omitempty"`
+	Won           bool      `protobuf:"varint,7,opt,name=won,proto3" json:"won,omitempty"`
+	Code          string    `protobuf:"bytes,8,opt,name=code,proto3" json:"code,omitempty"`
+	Shots         int32     `protobuf:"varint,9,opt,name=shots,proto3" json:"shots,omitempty"`
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Snapshot) GetState() GameState {
+	if x != nil {
+		return x.State
+	}
+	return GameState_GAME_STATE_UNSPECIFIED
+}
+
+func (x *Snapshot) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *Snapshot) GetOpponentBoard() *Board {
+	if x != nil {
+		return x.OpponentBoard
+	}
+	return nil
+}
+
+func (x *Snapshot) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *Snapshot) GetOpponentReady() bool {
+	if x != nil {
+		return x.OpponentReady
+	}
+	return false
+}
+
+func (x *Snapshot) GetMyTurn() bool {
+	if x != nil {
+		return x.MyTurn
+	}
+	return false
+}
+
+func (x *Snapshot) GetWon() bool {
+	if x != nil {
+		return x.Won
+	}
+	return false
+}
+
+func (x *Snapshot) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Snapshot) GetShots() int32 {
+	if x != nil {
+		return x.Shots
+	}
+	return 0
+}
+
+// Spectate is sent by client to watch running public game, server responds with SpectatorSnapshot, after that
+// spectator receives SpectatorSnapshot when game starts or ends and ShotResult or SalvoResult after each shot, where
+// by_opponent is set for shots of the guest at host's board, spectator can't send game events
+type Spectate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId     *UUID `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	HideFleets bool  `protobuf:"varint,2,opt,name=hide_fleets,json=hideFleets,proto3" json:"hide_fleets,omitempty"`
+}
+
+func (x *Spectate) Reset() {
+	*x = Spectate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Spectate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Spectate) ProtoMessage() {}
+
+func (x *Spectate) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Spectate.ProtoReflect.Descriptor instead.
+func (*Spectate) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Spectate) GetGameId() *UUID {
+	if x != nil {
+		return x.GameId
+	}
+	return nil
+}
+
+func (x *Spectate) GetHideFleets() bool {
+	if x != nil {
+		return x.HideFleets
+	}
+	return false
+}
+
+// SpectatorSnapshot is a full state of the game as seen by spectator, ships that were not hit are hidden until the end
+// if spectator asked so
+type SpectatorSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State      GameState `protobuf:"varint,1,opt,name=state,proto3,enum=api.GameState" json:"state,omitempty"`
+	HostName   string    `protobuf:"bytes,2,opt,name=host_name,json=hostName,proto3" json:"host_name,omitempty"`
+	Rules      *RuleSet  `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"`
+	HostBoard  *Board    `protobuf:"bytes,4,opt,name=host_board,json=hostBoard,proto3" json:"host_board,omitempty"`
+	GuestBoard *Board    `protobuf:"bytes,5,opt,name=guest_board,json=guestBoard,proto3" json:"guest_board,omitempty"`
+	HostTurn   bool      `protobuf:"varint,6,opt,name=host_turn,json=hostTurn,proto3" json:"host_turn,omitempty"`
+}
+
+func (x *SpectatorSnapshot) Reset() {
+	*x = SpectatorSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpectatorSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectatorSnapshot) ProtoMessage() {}
+
+func (x *SpectatorSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectatorSnapshot.ProtoReflect.Descriptor instead.
+func (*SpectatorSnapshot) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SpectatorSnapshot) GetState() GameState {
+	if x != nil {
+		return x.State
+	}
+	return GameState_GAME_STATE_UNSPECIFIED
+}
+
+func (x *SpectatorSnapshot) GetHostName() string {
+	if x != nil {
+		return x.HostName
+	}
+	return ""
+}
+
+func (x *SpectatorSnapshot) GetRules() *RuleSet {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *SpectatorSnapshot) GetHostBoard() *Board {
+	if x != nil {
+		return x.HostBoard
+	}
+	return nil
+}
+
+func (x *SpectatorSnapshot) GetGuestBoard() *Board {
+	if x != nil {
+		return x.GuestBoard
+	}
+	return nil
+}
+
+func (x *SpectatorSnapshot) GetHostTurn() bool {
+	if x != nil {
+		return x.HostTurn
+	}
+	return false
+}
+
+// OpponentLeft is sent by server when opponent disconnected before the end of the game
+type OpponentLeft struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OpponentLeft) Reset() {
+	*x = OpponentLeft{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpponentLeft) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpponentLeft) ProtoMessage() {}
+
+func (x *OpponentLeft) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpponentLeft.ProtoReflect.Descriptor instead.
+func (*OpponentLeft) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{23}
+}
+
+// Error is sent by server when client event was rejected
+type Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_event_manager_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_event_manager_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_event_manager_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_event_manager_proto protoreflect.FileDescriptor
+
+var file_event_manager_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x61, 0x70, 0x69, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x92, 0x07, 0x0a, 0x05,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x55, 0x49, 0x44, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x29, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x67, 0x61, 0x6d, 0x65,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x65, 0x77,
+	0x47, 0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x47, 0x61, 0x6d, 0x65, 0x12,
+	0x2f, 0x0a, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x61,
+	0x6d, 0x65, 0x73, 0x48, 0x00, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x73,
+	0x12, 0x2c, 0x0a, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61,
+	0x6d, 0x65, 0x48, 0x00, 0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x22,
+	0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x61,
+	0x64, 0x79, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x68, 0x6f, 0x6f, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x68, 0x6f, 0x6f, 0x74, 0x48, 0x00, 0x52,
+	0x05, 0x73, 0x68, 0x6f, 0x6f, 0x74, 0x12, 0x32, 0x0a, 0x0b, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x53, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x0a,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2f, 0x0a, 0x0a, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x64, 0x65, 0x64, 0x48, 0x00,
+	0x52, 0x09, 0x67, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x35, 0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x61, 0x6d, 0x65,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0b, 0x67, 0x61, 0x6d, 0x65, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x0d, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x4f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x66, 0x74,
+	0x48, 0x00, 0x52, 0x0c, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x66, 0x74,
+	0x12, 0x25, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x48, 0x00, 0x52,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x35, 0x0a, 0x0c, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x65, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0b,
+	0x67, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x05, 0x73,
+	0x61, 0x6c, 0x76, 0x6f, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x53, 0x61, 0x6c, 0x76, 0x6f, 0x48, 0x00, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x76, 0x6f, 0x12,
+	0x35, 0x0a, 0x0c, 0x73, 0x61, 0x6c, 0x76, 0x6f, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x61, 0x6c, 0x76,
+	0x6f, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x61, 0x6c, 0x76, 0x6f,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2b, 0x0a, 0x08, 0x73, 0x70, 0x65, 0x63, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53,
+	0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x08, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x47, 0x0a, 0x12, 0x73, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x6f, 0x72,
+	0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x11, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x61, 0x74, 0x6f, 0x72, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1f, 0x0a, 0x04,
+	0x63, 0x68, 0x61, 0x74, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x43, 0x68, 0x61, 0x74, 0x48, 0x00, 0x52, 0x04, 0x63, 0x68, 0x61, 0x74, 0x42, 0x09, 0x0a,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04,
+	0x08, 0x03, 0x10, 0x04, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x1c, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x23,
+	0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x01, 0x79, 0x22, 0x28, 0x0a, 0x04, 0x53, 0x68, 0x69, 0x70, 0x12, 0x20, 0x0a, 0x05, 0x63,
+	0x65, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0xd3, 0x01,
+	0x0a, 0x07, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x6c, 0x65, 0x65, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x66, 0x6c, 0x65, 0x65,
+	0x74, 0x12, 0x29, 0x0a, 0x08, 0x74, 0x6f, 0x75, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x6f, 0x75, 0x63, 0x68, 0x69,
+	0x6e, 0x67, 0x52, 0x08, 0x74, 0x6f, 0x75, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x05,
+	0x73, 0x61, 0x6c, 0x76, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x53, 0x61, 0x6c, 0x76, 0x6f, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x73, 0x61, 0x6c,
+	0x76, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6c, 0x76, 0x6f, 0x5f, 0x73, 0x68, 0x6f, 0x74,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x61, 0x6c, 0x76, 0x6f, 0x53, 0x68,
+	0x6f, 0x74, 0x73, 0x22, 0x64, 0x0a, 0x07, 0x4e, 0x65, 0x77, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x53,
+	0x65, 0x74, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x6a, 0x0a, 0x0b, 0x47, 0x61, 0x6d,
+	0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x55, 0x55, 0x49, 0x44, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x98, 0x01, 0x0a, 0x08, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x19, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x55, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x73, 0x65,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x75, 0x6c, 0x65, 0x53, 0x65, 0x74,
+	0x22, 0x50, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x23, 0x0a,
+	0x05, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x67, 0x61, 0x6d,
+	0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4a, 0x04, 0x08, 0x01,
+	0x10, 0x02, 0x22, 0x8b, 0x01, 0x0a, 0x08, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12,
+	0x22, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x55, 0x49, 0x44, 0x52, 0x06, 0x67, 0x61, 0x6d,
+	0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x22, 0x0a, 0x05,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73,
+	0x22, 0x44, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61,
+	0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12,
+	0x1f, 0x0a, 0x05, 0x73, 0x68, 0x69, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x68, 0x69, 0x70, 0x52, 0x05, 0x73, 0x68, 0x69, 0x70, 0x73,
+	0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x25, 0x0a, 0x05, 0x53, 0x68, 0x6f, 0x6f, 0x74, 0x12,
+	0x1c, 0x0a, 0x03, 0x70, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x03, 0x70, 0x6f, 0x73, 0x22, 0x2d, 0x0a,
+	0x05, 0x53, 0x61, 0x6c, 0x76, 0x6f, 0x12, 0x24, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x77, 0x0a, 0x0a,
+	0x53, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1c, 0x0a, 0x03, 0x70, 0x6f,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x52, 0x03, 0x70, 0x6f, 0x73, 0x12, 0x2a, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x53, 0x68, 0x6f, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x07, 0x6f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x79, 0x5f, 0x6f, 0x70, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x62, 0x79, 0x4f, 0x70, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x22, 0x4e, 0x0a, 0x0b, 0x53, 0x61, 0x6c, 0x76, 0x6f, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x68, 0x6f, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0x50, 0x0a, 0x09, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x64,
+	0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x77, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x03, 0x77, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x0e, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x5f, 0x73, 0x68, 0x69, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0d, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x53, 0x68, 0x69, 0x70, 0x73, 0x22, 0x3c, 0x0a, 0x0b, 0x47, 0x61, 0x6d, 0x65, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x79, 0x5f, 0x74, 0x75, 0x72,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6d, 0x79, 0x54, 0x75, 0x72, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0x3b, 0x0a, 0x04, 0x43, 0x68, 0x61, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x79, 0x5f, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x62, 0x79, 0x4f, 0x70, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x22, 0x2d, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x56, 0x0a, 0x05, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1f, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x65,
+	0x6c, 0x6c, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0x97, 0x02, 0x0a, 0x08, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x61, 0x6d, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x05,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x31,
+	0x0a, 0x0e, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x6f, 0x61,
+	0x72, 0x64, 0x52, 0x0d, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x42, 0x6f, 0x61, 0x72,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x70, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0d, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x17,
+	0x0a, 0x07, 0x6d, 0x79, 0x5f, 0x74, 0x75, 0x72, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x6d, 0x79, 0x54, 0x75, 0x72, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x77, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x77, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x68,
+	0x6f, 0x74, 0x73, 0x22, 0x4f, 0x0a, 0x08, 0x53, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x22, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x55, 0x49, 0x44, 0x52, 0x06, 0x67, 0x61, 0x6d,
+	0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x69, 0x64, 0x65, 0x5f, 0x66, 0x6c, 0x65, 0x65,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x68, 0x69, 0x64, 0x65, 0x46, 0x6c,
+	0x65, 0x65, 0x74, 0x73, 0x22, 0xef, 0x01, 0x0a, 0x11, 0x53, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74,
+	0x6f, 0x72, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a,
+	0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x12, 0x29, 0x0a, 0x0a, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x6f, 0x61, 0x72,
+	0x64, 0x52, 0x09, 0x68, 0x6f, 0x73, 0x74, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x2b, 0x0a, 0x0b,
+	0x67, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x0a, 0x67,
+	0x75, 0x65, 0x73, 0x74, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x73,
+	0x74, 0x5f, 0x74, 0x75, 0x72, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x68, 0x6f,
+	0x73, 0x74, 0x54, 0x75, 0x72, 0x6e, 0x22, 0x0e, 0x0a, 0x0c, 0x4f, 0x70, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x4c, 0x65, 0x66, 0x74, 0x22, 0x21, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2a, 0x46, 0x0a, 0x08, 0x54, 0x6f, 0x75,
+	0x63, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x4f, 0x55, 0x43, 0x48, 0x49, 0x4e,
+	0x47, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x4f, 0x55, 0x43,
+	0x48, 0x49, 0x4e, 0x47, 0x5f, 0x43, 0x4f, 0x52, 0x4e, 0x45, 0x52, 0x53, 0x10, 0x01, 0x12, 0x11,
+	0x0a, 0x0d, 0x54, 0x4f, 0x55, 0x43, 0x48, 0x49, 0x4e, 0x47, 0x5f, 0x46, 0x52, 0x45, 0x45, 0x10,
+	0x02, 0x2a, 0x4b, 0x0a, 0x09, 0x53, 0x61, 0x6c, 0x76, 0x6f, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x12,
+	0x0a, 0x0e, 0x53, 0x41, 0x4c, 0x56, 0x4f, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4f, 0x46, 0x46,
+	0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x41, 0x4c, 0x56, 0x4f, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x46, 0x49, 0x58, 0x45, 0x44, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x41, 0x4c, 0x56,
+	0x4f, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x48, 0x49, 0x50, 0x53, 0x10, 0x02, 0x2a, 0x74,
+	0x0a, 0x0b, 0x53, 0x68, 0x6f, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x1c, 0x0a,
+	0x18, 0x53, 0x48, 0x4f, 0x54, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x53,
+	0x48, 0x4f, 0x54, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x4d, 0x49, 0x53, 0x53,
+	0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x48, 0x4f, 0x54, 0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f,
+	0x4d, 0x45, 0x5f, 0x48, 0x49, 0x54, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x48, 0x4f, 0x54,
+	0x5f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x44, 0x45, 0x53, 0x54, 0x52, 0x4f, 0x59,
+	0x45, 0x44, 0x10, 0x03, 0x2a, 0x88, 0x01, 0x0a, 0x09, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16,
+	0x0a, 0x12, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x57, 0x41, 0x49,
+	0x54, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4c, 0x41, 0x43, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x16,
+	0x0a, 0x12, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x4c, 0x41,
+	0x59, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x49, 0x4e, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x04, 0x2a,
+	0x47, 0x0a, 0x04, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x45, 0x4c, 0x4c, 0x5f,
+	0x45, 0x4d, 0x50, 0x54, 0x59, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x45, 0x4c, 0x4c, 0x5f,
+	0x53, 0x48, 0x49, 0x50, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x45, 0x4c, 0x4c, 0x5f, 0x4d,
+	0x49, 0x53, 0x53, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x45, 0x4c, 0x4c, 0x5f, 0x53, 0x48,
+	0x49, 0x50, 0x5f, 0x48, 0x49, 0x54, 0x10, 0x03, 0x32, 0x36, 0x0a, 0x0c, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x06, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x0a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x0a,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01,
+	0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_event_manager_proto_rawDescOnce sync.Once
+	file_event_manager_proto_rawDescData = file_event_manager_proto_rawDesc
+)
+
+func file_event_manager_proto_rawDescGZIP() []byte {
+	file_event_manager_proto_rawDescOnce.Do(func() {
 		file_event_manager_proto_rawDescData = protoimpl.X.CompressGZIP(file_event_manager_proto_rawDescData)
 	})
 	return file_event_manager_proto_rawDescData
 }
 
-var file_event_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_event_manager_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_event_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_event_manager_proto_goTypes = []interface{}{
-	(*Event)(nil), // 0: api.Event
-	(*UUID)(nil),  // 1: api.UUID
+	(Touching)(0),                 // 0: api.Touching
+	(SalvoMode)(0),                // 1: api.SalvoMode
+	(ShotOutcome)(0),              // 2: api.ShotOutcome
+	(GameState)(0),                // 3: api.GameState
+	(Cell)(0),                     // 4: api.Cell
+	(*Event)(nil),                 // 5: api.Event
+	(*UUID)(nil),                  // 6: api.UUID
+	(*Point)(nil),                 // 7: api.Point
+	(*Ship)(nil),                  // 8: api.Ship
+	(*RuleSet)(nil),               // 9: api.RuleSet
+	(*NewGame)(nil),               // 10: api.NewGame
+	(*GameCreated)(nil),           // 11: api.GameCreated
+	(*GameInfo)(nil),              // 12: api.GameInfo
+	(*ListGames)(nil),             // 13: api.ListGames
+	(*JoinGame)(nil),              // 14: api.JoinGame
+	(*Ready)(nil),                 // 15: api.Ready
+	(*Shoot)(nil),                 // 16: api.Shoot
+	(*Salvo)(nil),                 // 17: api.Salvo
+	(*ShotResult)(nil),            // 18: api.ShotResult
+	(*SalvoResult)(nil),           // 19: api.SalvoResult
+	(*GameEnded)(nil),             // 20: api.GameEnded
+	(*GameStarted)(nil),           // 21: api.GameStarted
+	(*Chat)(nil),                  // 22: api.Chat
+	(*Resume)(nil),                // 23: api.Resume
+	(*Board)(nil),                 // 24: api.Board
+	(*Snapshot)(nil),              // 25: api.Snapshot
+	(*Spectate)(nil),              // 26: api.Spectate
+	(*SpectatorSnapshot)(nil),     // 27: api.SpectatorSnapshot
+	(*OpponentLeft)(nil),          // 28: api.OpponentLeft
+	(*Error)(nil),                 // 29: api.Error
+	(*timestamppb.Timestamp)(nil), // 30: google.protobuf.Timestamp
 }
 var file_event_manager_proto_depIdxs = []int32{
-	1, // 0: api.Event.from:type_name -> api.UUID
-	0, // 1: api.EventManager.Events:input_type -> api.Event
-	0, // 2: api.EventManager.Events:output_type -> api.Event
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	6,  // 0: api.Event.from:type_name -> api.UUID
+	10, // 1: api.Event.new_game:type_name -> api.NewGame
+	13, // 2: api.Event.list_games:type_name -> api.ListGames
+	14, // 3: api.Event.join_game:type_name -> api.JoinGame
+	15, // 4: api.Event.ready:type_name -> api.Ready
+	16, // 5: api.Event.shoot:type_name -> api.Shoot
+	18, // 6: api.Event.shot_result:type_name -> api.ShotResult
+	20, // 7: api.Event.game_ended:type_name -> api.GameEnded
+	29, // 8: api.Event.error:type_name -> api.Error
+	11, // 9: api.Event.game_created:type_name -> api.GameCreated
+	28, // 10: api.Event.opponent_left:type_name -> api.OpponentLeft
+	23, // 11: api.Event.resume:type_name -> api.Resume
+	25, // 12: api.Event.snapshot:type_name -> api.Snapshot
+	21, // 13: api.Event.game_started:type_name -> api.GameStarted
+	17, // 14: api.Event.salvo:type_name -> api.Salvo
+	19, // 15: api.Event.salvo_result:type_name -> api.SalvoResult
+	26, // 16: api.Event.spectate:type_name -> api.Spectate
+	27, // 17: api.Event.spectator_snapshot:type_name -> api.SpectatorSnapshot
+	22, // 18: api.Event.chat:type_name -> api.Chat
+	7,  // 19: api.Ship.cells:type_name -> api.Point
+	0,  // 20: api.RuleSet.touching:type_name -> api.Touching
+	1,  // 21: api.RuleSet.salvo:type_name -> api.SalvoMode
+	9,  // 22: api.NewGame.rules:type_name -> api.RuleSet
+	6,  // 23: api.GameCreated.game_id:type_name -> api.UUID
+	6,  // 24: api.GameInfo.id:type_name -> api.UUID
+	30, // 25: api.GameInfo.created_at:type_name -> google.protobuf.Timestamp
+	12, // 26: api.ListGames.games:type_name -> api.GameInfo
+	6,  // 27: api.JoinGame.game_id:type_name -> api.UUID
+	9,  // 28: api.JoinGame.rules:type_name -> api.RuleSet
+	8,  // 29: api.Ready.ships:type_name -> api.Ship
+	7,  // 30: api.Shoot.pos:type_name -> api.Point
+	7,  // 31: api.Salvo.targets:type_name -> api.Point
+	7,  // 32: api.ShotResult.pos:type_name -> api.Point
+	2,  // 33: api.ShotResult.outcome:type_name -> api.ShotOutcome
+	18, // 34: api.SalvoResult.results:type_name -> api.ShotResult
+	7,  // 35: api.GameEnded.opponent_ships:type_name -> api.Point
+	4,  // 36: api.Board.cells:type_name -> api.Cell
+	3,  // 37: api.Snapshot.state:type_name -> api.GameState
+	24, // 38: api.Snapshot.board:type_name -> api.Board
+	24, // 39: api.Snapshot.opponent_board:type_name -> api.Board
+	6,  // 40: api.Spectate.game_id:type_name -> api.UUID
+	3,  // 41: api.SpectatorSnapshot.state:type_name -> api.GameState
+	9,  // 42: api.SpectatorSnapshot.rules:type_name -> api.RuleSet
+	24, // 43: api.SpectatorSnapshot.host_board:type_name -> api.Board
+	24, // 44: api.SpectatorSnapshot.guest_board:type_name -> api.Board
+	5,  // 45: api.EventManager.Events:input_type -> api.Event
+	5,  // 46: api.EventManager.Events:output_type -> api.Event
+	46, // [46:47] is the sub-list for method output_type
+	45, // [45:46] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_event_manager_proto_init() }
@@ -207,20 +2449,316 @@ func file_event_manager_proto_init() {
 				return nil
 			}
 		}
+		file_event_manager_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Point); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ship); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuleSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewGame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameCreated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGames); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinGame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ready); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Shoot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Salvo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShotResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SalvoResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameEnded); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameStarted); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Chat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resume); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Board); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Spectate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpectatorSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpponentLeft); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_event_manager_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Error); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_event_manager_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Event_NewGame)(nil),
+		(*Event_ListGames)(nil),
+		(*Event_JoinGame)(nil),
+		(*Event_Ready)(nil),
+		(*Event_Shoot)(nil),
+		(*Event_ShotResult)(nil),
+		(*Event_GameEnded)(nil),
+		(*Event_Error)(nil),
+		(*Event_GameCreated)(nil),
+		(*Event_OpponentLeft)(nil),
+		(*Event_Resume)(nil),
+		(*Event_Snapshot)(nil),
+		(*Event_GameStarted)(nil),
+		(*Event_Salvo)(nil),
+		(*Event_SalvoResult)(nil),
+		(*Event_Spectate)(nil),
+		(*Event_SpectatorSnapshot)(nil),
+		(*Event_Chat)(nil),
 	}
-	file_event_manager_proto_msgTypes[0].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_event_manager_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      5,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_event_manager_proto_goTypes,
 		DependencyIndexes: file_event_manager_proto_depIdxs,
+		EnumInfos:         file_event_manager_proto_enumTypes,
 		MessageInfos:      file_event_manager_proto_msgTypes,
 	}.Build()
 	File_event_manager_proto = out.File
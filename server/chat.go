@@ -0,0 +1,92 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mymmrac/battleship/server/api"
+)
+
+const (
+	// MaxChatMessageLen is a maximal number of characters in chat message
+	MaxChatMessageLen = 200
+
+	// Player can send at most chatRateLimit messages per chatRateInterval
+	chatRateLimit    = 5
+	chatRateInterval = 10 * time.Second
+)
+
+var (
+	ErrChatEmpty     = errors.New("empty chat message")
+	ErrChatTooLong   = fmt.Errorf("chat message is longer than %d characters", MaxChatMessageLen)
+	ErrChatRateLimit = errors.New("too many chat messages, slow down")
+)
+
+// Chat relays message to both players, control characters are replaced with spaces
+func (g *MultiplayerGame) Chat(player *Player, text string) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.state == GameStateWaiting {
+		return errors.New("no one to chat with")
+	}
+
+	text, err := chatText(text)
+	if err != nil {
+		return err
+	}
+
+	_, opponent := g.players(player)
+	if opponent == nil {
+		return ErrNotInGame
+	}
+
+	if !player.allowChat(time.Now()) {
+		return ErrChatRateLimit
+	}
+
+	player.Send(&api.Event{Payload: &api.Event_Chat{Chat: &api.Chat{Text: text, ByOpponent: false}}})
+	opponent.Send(&api.Event{Payload: &api.Event_Chat{Chat: &api.Chat{Text: text, ByOpponent: true}}})
+
+	return nil
+}
+
+// chatText cleans up chat message and checks its length
+func chatText(text string) (string, error) {
+	text = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, text))
+
+	if text == "" {
+		return "", ErrChatEmpty
+	}
+	if len([]rune(text)) > MaxChatMessageLen {
+		return "", ErrChatTooLong
+	}
+
+	return text, nil
+}
+
+// allowChat reports whether player can send chat message now and if so counts it, must be called with game lock held
+func (p *Player) allowChat(now time.Time) bool {
+	recent := p.chatSent[:0]
+	for _, sent := range p.chatSent {
+		if now.Sub(sent) < chatRateInterval {
+			recent = append(recent, sent)
+		}
+	}
+	p.chatSent = recent
+
+	if len(p.chatSent) >= chatRateLimit {
+		return false
+	}
+
+	p.chatSent = append(p.chatSent, now)
+	return true
+}
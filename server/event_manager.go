@@ -3,125 +3,245 @@
 package server
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/rules"
 	"github.com/mymmrac/battleship/server/api"
 )
 
-type Player struct {
-	ID     uuid.UUID
-	Events chan events.ServerEvent
-}
-
-func (p *Player) HandleEvents(stream api.EventManager_EventsServer) {
-	for event := range p.Events {
-		err := stream.Send(event.ToGRPC())
-		if err != nil {
-			fmt.Println(err) // FIXME
-		}
-	}
-}
-
-type MultiplayerGame struct {
-	playerA *Player
-	playerB *Player
-}
-
 type EventManagerServer struct {
 	api.UnimplementedEventManagerServer
 
-	games map[uuid.UUID]*MultiplayerGame
+	registry    *GameRegistry
+	gracePeriod time.Duration
 }
 
-func NewEventManagerServer() *EventManagerServer {
+// NewEventManagerServer creates server, disconnected players can resume their games during grace period
+func NewEventManagerServer(gracePeriod time.Duration) *EventManagerServer {
 	return &EventManagerServer{
-		games: map[uuid.UUID]*MultiplayerGame{},
+		registry:    NewGameRegistry(),
+		gracePeriod: gracePeriod,
 	}
 }
 
 func (e *EventManagerServer) Events(stream api.EventManager_EventsServer) error {
+	var player *Player
+	defer func() {
+		if player != nil {
+			e.disconnect(player)
+		}
+	}()
+
+	received, receiveErr := receiveEvents(stream)
+
+	// Player closed by server is disconnected, stream is ended by returning, done is nil until player is known
+	var done <-chan struct{}
+
 	for {
-		grpcEvent, err := stream.Recv()
-		if err != nil {
+		var event *api.Event
+		select {
+		case event = <-received:
+			// Pass
+		case err := <-receiveErr:
 			return err
+		case <-done:
+			return player.Err()
 		}
 
-		event := events.ServerEventFromGRPC(grpcEvent)
-		fmt.Printf("Event: %d, from %s, data: %v\n", event.Type, event.From, event.Data)
+		from, err := events.UUIDFromGRPC(event.GetFrom())
+		if err != nil {
+			return fmt.Errorf("bad sender ID: %w", err)
+		}
 
-		switch event.Type {
-		case events.ServerEventNewGame:
-			player := &Player{
-				ID:     event.From,
-				Events: make(chan events.ServerEvent),
-			}
-			e.games[event.From] = &MultiplayerGame{
-				playerA: player,
-			}
+		if player == nil {
+			player = NewPlayer(from)
+			done = player.Done()
 			go player.HandleEvents(stream)
-		case events.ServerEventListGames:
-			games := make([]uuid.UUID, 0, len(e.games))
-			for id, g := range e.games {
-				if g.playerB == nil && id == g.playerA.ID {
-					games = append(games, g.playerA.ID)
+		} else if player.ID != from {
+			return errors.New("sender ID changed")
+		}
+
+		fmt.Printf("Event: %T, from %s\n", event.GetPayload(), from)
+
+		switch payload := event.GetPayload().(type) {
+		case *api.Event_NewGame:
+			ruleSet := rules.DefaultRuleSet
+			if payload.NewGame.GetRules() != nil {
+				ruleSet, err = events.RuleSetFromGRPC(payload.NewGame.GetRules())
+				if err != nil {
+					player.SendError(err)
+					continue
 				}
 			}
 
-			var data []byte
-			data, err = json.Marshal(games)
+			var game *MultiplayerGame
+			game, err = e.registry.Create(player, payload.NewGame.GetHostName(), payload.NewGame.GetPrivate(), ruleSet)
 			if err != nil {
-				return err
+				player.SendError(err)
+				continue
 			}
 
-			err = stream.Send(events.ServerEvent{
-				Type: events.ServerEventListGames,
-				From: uuid.Nil,
-				Data: data,
-			}.ToGRPC())
-			if err != nil {
-				return err
+			player.Send(&api.Event{Payload: &api.Event_GameCreated{GameCreated: &api.GameCreated{
+				GameId:       events.UUIDToGRPC(game.ID()),
+				Code:         game.code,
+				SessionToken: player.token,
+			}}})
+		case *api.Event_ListGames:
+			listed := e.registry.Open()
+			if payload.ListGames.GetRunning() {
+				listed = e.registry.Running()
 			}
-		case events.ServerEventJoinGame:
+
+			games := make([]*api.GameInfo, 0, len(listed))
+			for _, game := range listed {
+				games = append(games, game.Info())
+			}
+
+			player.Send(&api.Event{Payload: &api.Event_ListGames{ListGames: &api.ListGames{
+				Games:   games,
+				Running: payload.ListGames.GetRunning(),
+			}}})
+		case *api.Event_JoinGame:
+			if _, err = e.registry.Join(player, payload.JoinGame); err != nil {
+				player.SendError(err)
+			}
+		case *api.Event_Spectate:
 			var gameID uuid.UUID
-			gameID, err = uuid.FromBytes(event.Data)
+			gameID, err = events.UUIDFromGRPC(payload.Spectate.GetGameId())
 			if err != nil {
-				return err
+				player.SendError(err)
+				continue
 			}
 
-			game := e.games[gameID]
-			e.games[event.From] = game
-
-			player := &Player{
-				ID:     event.From,
-				Events: make(chan events.ServerEvent),
+			if _, err = e.registry.Spectate(player, gameID, payload.Spectate.GetHideFleets()); err != nil {
+				player.SendError(err)
+				continue
 			}
-			game.playerB = player
-			go player.HandleEvents(stream)
 
-			gameEvent := events.GameEventSignal{Type: events.GameEventJoinedGame}
-			var data []byte
-			data, err = json.Marshal(gameEvent)
+			fmt.Printf("Player %s watches game %s\n", from, gameID)
+		case *api.Event_Resume:
+			var game *MultiplayerGame
+			game, err = e.registry.Resume(player, payload.Resume.GetSessionToken())
 			if err != nil {
-				return err
+				fmt.Printf("Resume of %s rejected: %s\n", from, err)
+				player.SendError(err)
+				continue
+			}
+
+			fmt.Printf("Player %s resumed game %s\n", from, game.ID())
+			if game.State() == GameStateFinished && game.Connected() {
+				e.removeGame(game)
 			}
+		case *api.Event_Ready:
+			e.handleGameEvent(player, func(game *MultiplayerGame) error {
+				return game.Ready(player, payload.Ready)
+			})
+		case *api.Event_Shoot:
+			e.handleGameEvent(player, func(game *MultiplayerGame) error {
+				return game.Shoot(player, events.PointFromGRPC(payload.Shoot.GetPos()))
+			})
+		case *api.Event_Salvo:
+			e.handleGameEvent(player, func(game *MultiplayerGame) error {
+				return game.Salvo(player, events.PointsFromGRPC(payload.Salvo.GetTargets()))
+			})
+		case *api.Event_Chat:
+			e.handleGameEvent(player, func(game *MultiplayerGame) error {
+				return game.Chat(player, payload.Chat.GetText())
+			})
+		default:
+			fmt.Printf("Unexpected event from %s: %T\n", from, payload)
+		}
+	}
+}
 
-			game.playerA.Events <- events.ServerEvent{
-				Type: events.ServerEventGameEvent,
-				From: uuid.Nil,
-				Data: data,
+// receiveEvents reads events from the stream in background until stream ends, so that waiting for them can be
+// interrupted
+func receiveEvents(stream api.EventManager_EventsServer) (<-chan *api.Event, <-chan error) {
+	received := make(chan *api.Event)
+	receiveErr := make(chan error, 1)
+
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				receiveErr <- err
+				return
 			}
-		case events.ServerEventGameEvent:
-			game := e.games[event.From]
 
-			if event.From == game.playerA.ID {
-				game.playerB.Events <- event
-			} else {
-				game.playerA.Events <- event
+			select {
+			case received <- event:
+			case <-stream.Context().Done():
+				return
 			}
 		}
+	}()
+
+	return received, receiveErr
+}
+
+func (e *EventManagerServer) handleGameEvent(player *Player, handler func(game *MultiplayerGame) error) {
+	if e.registry.Spectating(player) {
+		fmt.Printf("Game event from spectator: %s\n", player.ID)
+		player.SendError(ErrSpectatorAction)
+		return
+	}
+
+	game, ok := e.registry.ByPlayer(player)
+	if !ok {
+		fmt.Printf("Game event from player not in game: %s\n", player.ID)
+		player.SendError(ErrGameNotFound)
+		return
+	}
+
+	if err := handler(game); err != nil {
+		fmt.Printf("Game event from %s rejected: %s\n", player.ID, err)
+		player.SendError(err)
+	}
+
+	// Finished game is kept until disconnected players resume it or their grace period ends
+	if game.State() == GameStateFinished && game.Connected() {
+		e.removeGame(game)
 	}
 }
+
+// disconnect is called when player's stream ends, player's seat is held for grace period and if player doesn't
+// resume, game that player was in is finished and removed
+func (e *EventManagerServer) disconnect(player *Player) {
+	player.Close()
+	fmt.Printf("Player %s disconnected\n", player.ID)
+
+	e.registry.Unspectate(player)
+
+	game, ok := e.registry.ByPlayer(player)
+	if !ok {
+		return
+	}
+
+	if e.gracePeriod <= 0 {
+		e.leave(game, player)
+		return
+	}
+
+	time.AfterFunc(e.gracePeriod, func() {
+		e.leave(game, player)
+	})
+}
+
+func (e *EventManagerServer) leave(game *MultiplayerGame, player *Player) {
+	if !game.leave(player) {
+		return
+	}
+
+	fmt.Printf("Player %s left game %s\n", player.ID, game.ID())
+	e.removeGame(game)
+}
+
+func (e *EventManagerServer) removeGame(game *MultiplayerGame) {
+	e.registry.Remove(game)
+	fmt.Printf("Game %s removed, %d games left\n", game.ID(), e.registry.Len())
+}
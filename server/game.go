@@ -0,0 +1,522 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mymmrac/battleship/data"
+	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server/api"
+)
+
+type GameState int
+
+const (
+	_ GameState = iota
+	GameStateWaiting
+	GameStatePlacing
+	GameStatePlaying
+	GameStateFinished
+)
+
+func (s GameState) String() string {
+	switch s {
+	case GameStateWaiting:
+		return "waiting"
+	case GameStatePlacing:
+		return "placing"
+	case GameStatePlaying:
+		return "playing"
+	case GameStateFinished:
+		return "finished"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+type MultiplayerGame struct {
+	lock sync.Mutex
+
+	id        uuid.UUID
+	hostName  string
+	createdAt time.Time
+	private   bool
+	code      string
+	ruleSet   rules.RuleSet
+
+	state   GameState
+	playerA *Player
+	playerB *Player
+	turn    *Player
+
+	spectators map[*Player]spectator
+}
+
+// spectator watches the game, hidden fleets are revealed only when game ends
+type spectator struct {
+	player     *Player
+	hideFleets bool
+}
+
+func NewMultiplayerGame(host *Player, hostName string, private bool, ruleSet rules.RuleSet) *MultiplayerGame {
+	return &MultiplayerGame{
+		id:        uuid.New(),
+		hostName:  hostName,
+		createdAt: time.Now(),
+		private:   private,
+		ruleSet:   ruleSet,
+		state:     GameStateWaiting,
+		playerA:   host,
+
+		spectators: map[*Player]spectator{},
+	}
+}
+
+func (g *MultiplayerGame) ID() uuid.UUID {
+	return g.id
+}
+
+func (g *MultiplayerGame) State() GameState {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.state
+}
+
+func (g *MultiplayerGame) Info() *api.GameInfo {
+	return &api.GameInfo{
+		Id:        events.UUIDToGRPC(g.id),
+		HostName:  g.hostName,
+		CreatedAt: timestamppb.New(g.createdAt),
+		RuleSet:   g.ruleSet.Name,
+	}
+}
+
+// players returns seat of the player and seat of the opponent, seat is nil if player doesn't play in the game, must be
+// called with lock held
+func (g *MultiplayerGame) players(player *Player) (*Player, *Player) {
+	switch player {
+	case g.playerA:
+		return g.playerA, g.playerB
+	case g.playerB:
+		return g.playerB, g.playerA
+	default:
+		return nil, nil
+	}
+}
+
+func (g *MultiplayerGame) join(player *Player) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.state != GameStateWaiting {
+		return errors.New("game already started")
+	}
+
+	// Host may never come back, so the game can't be joined until host resumes it
+	if !g.playerA.Connected() {
+		return ErrGameNotFound
+	}
+
+	g.playerB = player
+	g.state = GameStatePlacing
+
+	player.Send(&api.Event{Payload: &api.Event_JoinGame{
+		JoinGame: &api.JoinGame{
+			GameId:       events.UUIDToGRPC(g.id),
+			SessionToken: player.token,
+			Rules:        events.RuleSetToGRPC(g.ruleSet),
+		},
+	}})
+	g.playerA.Send(&api.Event{Payload: &api.Event_JoinGame{
+		JoinGame: &api.JoinGame{GameId: events.UUIDToGRPC(g.id)},
+	}})
+
+	return nil
+}
+
+// leave finishes the game because player left and notifies the opponent if game was not finished yet, returns false if
+// player's seat was already taken back by resume
+func (g *MultiplayerGame) leave(player *Player) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	seat, opponent := g.players(player)
+	if seat == nil {
+		return false
+	}
+
+	if g.state == GameStateFinished {
+		return true
+	}
+
+	if opponent != nil {
+		opponent.Send(&api.Event{Payload: &api.Event_OpponentLeft{OpponentLeft: &api.OpponentLeft{}}})
+	}
+	if g.state == GameStatePlaying {
+		g.logBoards("abandoned")
+	}
+	g.state = GameStateFinished
+	g.sendSpectatorSnapshots()
+
+	return true
+}
+
+// resume replaces seat that session token belongs to with the newly connected player and sends it a game snapshot,
+// returns replaced seat
+func (g *MultiplayerGame) resume(player *Player, token string) (*Player, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	var seat *Player
+	switch {
+	case g.playerA.token == token:
+		seat = g.playerA
+		g.playerA = player
+	case g.playerB != nil && g.playerB.token == token:
+		seat = g.playerB
+		g.playerB = player
+	default:
+		return nil, errors.New("invalid session")
+	}
+
+	seat.Close()
+	player.token = seat.token
+	player.board = seat.board
+	player.chatSent = seat.chatSent
+	if g.turn == seat {
+		g.turn = player
+	}
+
+	player.Send(&api.Event{Payload: &api.Event_Snapshot{Snapshot: g.snapshot(player)}})
+	return seat, nil
+}
+
+// snapshot returns game state as seen by player, must be called with lock held
+func (g *MultiplayerGame) snapshot(player *Player) *api.Snapshot {
+	_, opponent := g.players(player)
+
+	board := player.board
+	if board == nil {
+		board = rules.NewBoard(g.ruleSet)
+	}
+
+	opponentReady := opponent != nil && opponent.board != nil
+	opponentBoard := rules.NewBoard(g.ruleSet)
+	if opponentReady {
+		opponentBoard = opponent.board
+	}
+
+	myTurn := g.state == GameStatePlaying && g.turn == player
+	shots := 0
+	if myTurn {
+		shots = rules.ShotsPerTurn(player.board, opponent.board)
+	}
+
+	return &api.Snapshot{
+		State:         gameStateToGRPC(g.state),
+		Board:         events.BoardToGRPC(board, false),
+		OpponentBoard: events.BoardToGRPC(opponentBoard, true),
+		Ready:         player.board != nil,
+		OpponentReady: opponentReady,
+		MyTurn:        myTurn,
+		Won:           g.state == GameStateFinished && opponentReady && !opponent.board.HasAlive(),
+		Code:          g.code,
+		Shots:         int32(shots),
+	}
+}
+
+// Connected reports whether all players in the game are connected
+func (g *MultiplayerGame) Connected() bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.playerA.Connected() && (g.playerB == nil || g.playerB.Connected())
+}
+
+func gameStateToGRPC(state GameState) api.GameState {
+	switch state {
+	case GameStateWaiting:
+		return api.GameState_GAME_STATE_WAITING
+	case GameStatePlacing:
+		return api.GameState_GAME_STATE_PLACING
+	case GameStatePlaying:
+		return api.GameState_GAME_STATE_PLAYING
+	case GameStateFinished:
+		return api.GameState_GAME_STATE_FINISHED
+	default:
+		return api.GameState_GAME_STATE_UNSPECIFIED
+	}
+}
+
+func (g *MultiplayerGame) Ready(player *Player, ready *api.Ready) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.state != GameStatePlacing {
+		return fmt.Errorf("can't change ready state in %s game", g.state)
+	}
+
+	_, opponent := g.players(player)
+	if opponent == nil {
+		return ErrNotInGame
+	}
+
+	if !ready.GetReady() {
+		player.board = nil
+		if g.turn == player {
+			g.turn = nil
+		}
+
+		opponent.Send(&api.Event{Payload: &api.Event_Ready{Ready: &api.Ready{Ready: false}}})
+		return nil
+	}
+
+	board := rules.NewBoard(g.ruleSet)
+	if err := board.PlaceFleet(events.ShipsFromGRPC(ready.GetShips())); err != nil {
+		return fmt.Errorf("invalid fleet: %w", err)
+	}
+	player.board = board
+
+	opponent.Send(&api.Event{Payload: &api.Event_Ready{Ready: &api.Ready{Ready: true}}})
+
+	// Player who got ready first, shoots first
+	if opponent.board == nil {
+		g.turn = player
+		return nil
+	}
+	g.state = GameStatePlaying
+	g.logBoards("started")
+	g.sendSpectatorSnapshots()
+
+	player.Send(&api.Event{Payload: &api.Event_GameStarted{GameStarted: &api.GameStarted{MyTurn: false}}})
+	opponent.Send(&api.Event{Payload: &api.Event_GameStarted{GameStarted: &api.GameStarted{
+		MyTurn: true,
+		Shots:  int32(rules.ShotsPerTurn(opponent.board, player.board)),
+	}}})
+	return nil
+}
+
+func (g *MultiplayerGame) Shoot(player *Player, pos data.Point[int]) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.state != GameStatePlaying {
+		return fmt.Errorf("can't shoot in %s game", g.state)
+	}
+
+	if g.turn != player {
+		return errors.New("not player's turn")
+	}
+
+	if g.ruleSet.Salvo != rules.SalvoOff {
+		return errors.New("single shot in salvo game")
+	}
+
+	_, opponent := g.players(player)
+
+	result, err := opponent.board.Shoot(pos)
+	if err != nil {
+		return err
+	}
+
+	if result == rules.ShotMiss {
+		g.turn = opponent
+	}
+
+	outcome := events.ShotResultToGRPC(result)
+	player.Send(&api.Event{Payload: &api.Event_ShotResult{ShotResult: &api.ShotResult{
+		Pos:        events.PointToGRPC(pos),
+		Outcome:    outcome,
+		ByOpponent: false,
+	}}})
+	opponent.Send(&api.Event{Payload: &api.Event_ShotResult{ShotResult: &api.ShotResult{
+		Pos:        events.PointToGRPC(pos),
+		Outcome:    outcome,
+		ByOpponent: true,
+	}}})
+	g.sendSpectators(func(_ bool) *api.Event {
+		return &api.Event{Payload: &api.Event_ShotResult{ShotResult: &api.ShotResult{
+			Pos:        events.PointToGRPC(pos),
+			Outcome:    outcome,
+			ByOpponent: player == g.playerB,
+		}}}
+	})
+
+	if opponent.board.HasAlive() {
+		return nil
+	}
+	g.state = GameStateFinished
+	g.logBoards("finished")
+	g.sendSpectatorSnapshots()
+
+	player.Send(&api.Event{Payload: &api.Event_GameEnded{GameEnded: &api.GameEnded{
+		Won:           true,
+		OpponentShips: events.PointsToGRPC(opponent.board.FleetCells()),
+	}}})
+	opponent.Send(&api.Event{Payload: &api.Event_GameEnded{GameEnded: &api.GameEnded{
+		Won:           false,
+		OpponentShips: events.PointsToGRPC(player.board.FleetCells()),
+	}}})
+	return nil
+}
+
+// Salvo fires all shots of the turn at once, after that turn goes to opponent
+func (g *MultiplayerGame) Salvo(player *Player, targets []data.Point[int]) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.state != GameStatePlaying {
+		return fmt.Errorf("can't shoot in %s game", g.state)
+	}
+
+	if g.turn != player {
+		return errors.New("not player's turn")
+	}
+
+	if g.ruleSet.Salvo == rules.SalvoOff {
+		return errors.New("salvo in game without salvo")
+	}
+
+	_, opponent := g.players(player)
+
+	if err := rules.ValidateSalvo(player.board, opponent.board, targets); err != nil {
+		return err
+	}
+
+	results := make([]*api.ShotResult, 0, len(targets))
+	opponentResults := make([]*api.ShotResult, 0, len(targets))
+	for _, pos := range targets {
+		result, err := opponent.board.Shoot(pos)
+		if err != nil {
+			return err
+		}
+
+		outcome := events.ShotResultToGRPC(result)
+		results = append(results, &api.ShotResult{Pos: events.PointToGRPC(pos), Outcome: outcome})
+		opponentResults = append(opponentResults, &api.ShotResult{
+			Pos:        events.PointToGRPC(pos),
+			Outcome:    outcome,
+			ByOpponent: true,
+		})
+	}
+
+	won := !opponent.board.HasAlive()
+
+	opponentShots := 0
+	if !won {
+		g.turn = opponent
+		opponentShots = rules.ShotsPerTurn(opponent.board, player.board)
+	}
+
+	player.Send(&api.Event{Payload: &api.Event_SalvoResult{SalvoResult: &api.SalvoResult{Results: results}}})
+	opponent.Send(&api.Event{Payload: &api.Event_SalvoResult{SalvoResult: &api.SalvoResult{
+		Results: opponentResults,
+		Shots:   int32(opponentShots),
+	}}})
+	g.sendSpectators(func(_ bool) *api.Event {
+		spectatorResults := make([]*api.ShotResult, 0, len(results))
+		for _, result := range results {
+			spectatorResults = append(spectatorResults, &api.ShotResult{
+				Pos:        result.GetPos(),
+				Outcome:    result.GetOutcome(),
+				ByOpponent: player == g.playerB,
+			})
+		}
+
+		return &api.Event{Payload: &api.Event_SalvoResult{SalvoResult: &api.SalvoResult{Results: spectatorResults}}}
+	})
+
+	if !won {
+		return nil
+	}
+	g.state = GameStateFinished
+	g.logBoards("finished")
+	g.sendSpectatorSnapshots()
+
+	player.Send(&api.Event{Payload: &api.Event_GameEnded{GameEnded: &api.GameEnded{
+		Won:           true,
+		OpponentShips: events.PointsToGRPC(opponent.board.FleetCells()),
+	}}})
+	opponent.Send(&api.Event{Payload: &api.Event_GameEnded{GameEnded: &api.GameEnded{
+		Won:           false,
+		OpponentShips: events.PointsToGRPC(player.board.FleetCells()),
+	}}})
+	return nil
+}
+
+// logBoards prints boards of both players in board notation
+func (g *MultiplayerGame) logBoards(action string) {
+	fmt.Printf("Game %s %s, %s rules\nPlayer %s:\n%sPlayer %s:\n%s",
+		g.id, action, g.ruleSet, g.playerA.ID, g.playerA.board, g.playerB.ID, g.playerB.board)
+}
+
+// spectate adds spectator to the game and sends it a game snapshot, only games that are being played can be watched
+func (g *MultiplayerGame) spectate(player *Player, hideFleets bool) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.state != GameStatePlacing && g.state != GameStatePlaying {
+		return fmt.Errorf("can't watch %s game", g.state)
+	}
+
+	g.spectators[player] = spectator{player: player, hideFleets: hideFleets}
+	player.Send(g.spectatorSnapshot(hideFleets))
+	return nil
+}
+
+func (g *MultiplayerGame) unspectate(player *Player) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	delete(g.spectators, player)
+}
+
+// sendSpectators sends event created for each spectator, spectators that can't keep up are dropped, must be called
+// with lock held. Stream of dropped spectator ends with ErrTooSlow, after that it's removed from the registry on
+// disconnect
+func (g *MultiplayerGame) sendSpectators(event func(hideFleets bool) *api.Event) {
+	for player, s := range g.spectators {
+		if player.TrySend(event(s.hideFleets)) {
+			continue
+		}
+
+		fmt.Printf("Spectator %s of game %s is too slow, dropped\n", player.ID, g.id)
+		player.CloseWithError(ErrTooSlow)
+		delete(g.spectators, player)
+	}
+}
+
+func (g *MultiplayerGame) sendSpectatorSnapshots() {
+	g.sendSpectators(g.spectatorSnapshot)
+}
+
+// spectatorSnapshot returns game state as seen by spectator, must be called with lock held
+func (g *MultiplayerGame) spectatorSnapshot(hideFleets bool) *api.Event {
+	hideShips := hideFleets && g.state != GameStateFinished
+
+	hostBoard := rules.NewBoard(g.ruleSet)
+	if g.playerA.board != nil {
+		hostBoard = g.playerA.board
+	}
+
+	guestBoard := rules.NewBoard(g.ruleSet)
+	if g.playerB != nil && g.playerB.board != nil {
+		guestBoard = g.playerB.board
+	}
+
+	return &api.Event{Payload: &api.Event_SpectatorSnapshot{SpectatorSnapshot: &api.SpectatorSnapshot{
+		State:      gameStateToGRPC(g.state),
+		HostName:   g.hostName,
+		Rules:      events.RuleSetToGRPC(g.ruleSet),
+		HostBoard:  events.BoardToGRPC(hostBoard, hideShips),
+		GuestBoard: events.BoardToGRPC(guestBoard, hideShips),
+		HostTurn:   g.state == GameStatePlaying && g.turn == g.playerA,
+	}}}
+}
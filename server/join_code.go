@@ -0,0 +1,37 @@
+package server
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+const JoinCodeLength = 6
+
+// JoinCodeAlphabet contains characters used in join codes, similar looking characters (0/O, 1/I) are excluded
+const JoinCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+func newJoinCode() (string, error) {
+	alphabetLen := big.NewInt(int64(len(JoinCodeAlphabet)))
+
+	code := make([]byte, JoinCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetLen)
+		if err != nil {
+			return "", err
+		}
+
+		code[i] = JoinCodeAlphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
+
+// NormalizeJoinCode converts user input into join code form
+func NormalizeJoinCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func IsJoinCodeRune(r rune) bool {
+	return strings.ContainsRune(JoinCodeAlphabet, r)
+}
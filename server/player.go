@@ -0,0 +1,120 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server/api"
+)
+
+const playerEventsBuffer = 16
+
+// Player is a connected client, all events to the client are sent through it, so that stream is never written
+// concurrently
+type Player struct {
+	ID uuid.UUID
+
+	events    chan *api.Event
+	done      chan struct{}
+	closeOnce sync.Once
+	// closeErr is a reason why player was closed, player's stream ends with it
+	closeErr error
+
+	// token is a secret used to resume player's seat in the game after reconnect
+	token string
+	board *rules.Board
+
+	// chatSent holds times of recently sent chat messages, it's used for rate limiting
+	chatSent []time.Time
+}
+
+func NewPlayer(id uuid.UUID) *Player {
+	return &Player{
+		ID:     id,
+		events: make(chan *api.Event, playerEventsBuffer),
+		done:   make(chan struct{}),
+	}
+}
+
+func (p *Player) HandleEvents(stream api.EventManager_EventsServer) {
+	for {
+		select {
+		case event := <-p.events:
+			if err := stream.Send(event); err != nil {
+				fmt.Printf("Send to %s failed: %s\n", p.ID, err)
+				p.Close()
+				return
+			}
+		case <-p.done:
+			return
+		case <-stream.Context().Done():
+			p.Close()
+			return
+		}
+	}
+}
+
+// Send queues event for the player without waiting, so that it can be called with game lock held, player that can't
+// keep up is disconnected, events sent after player is closed are dropped
+func (p *Player) Send(event *api.Event) {
+	if !p.TrySend(event) {
+		fmt.Printf("Player %s is too slow, disconnected\n", p.ID)
+		p.CloseWithError(ErrTooSlow)
+	}
+}
+
+// TrySend queues event without waiting, reports false if player's queue is full, so that slow spectators never block
+// the game
+func (p *Player) TrySend(event *api.Event) bool {
+	event.From = events.UUIDToGRPC(uuid.Nil)
+
+	select {
+	case p.events <- event:
+		return true
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *Player) SendError(err error) {
+	p.Send(&api.Event{Payload: &api.Event_Error{Error: &api.Error{Message: err.Error()}}})
+}
+
+// Done is closed when player is closed, player's stream is ended after that
+func (p *Player) Done() <-chan struct{} {
+	return p.done
+}
+
+// Connected reports whether player's stream is still open
+func (p *Player) Connected() bool {
+	select {
+	case <-p.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (p *Player) Close() {
+	p.CloseWithError(ErrDisconnected)
+}
+
+// CloseWithError closes player, player's stream ends with err as a reason, only the first reason is kept
+func (p *Player) CloseWithError(err error) {
+	p.closeOnce.Do(func() {
+		p.closeErr = err
+		close(p.done)
+	})
+}
+
+// Err returns a reason why player was closed, must be called after Done is closed
+func (p *Player) Err() error {
+	return p.closeErr
+}
@@ -0,0 +1,275 @@
+package server
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server/api"
+)
+
+var (
+	ErrGameNotFound    = errors.New("game not found")
+	ErrAlreadyInGame   = errors.New("player already in game")
+	ErrNotInGame       = errors.New("player is not in the game")
+	ErrSpectatorAction = errors.New("spectators can't play")
+	ErrDisconnected    = errors.New("disconnected by server")
+	ErrTooSlow         = errors.New("too slow to receive events, disconnected by server")
+)
+
+// GameRegistry keeps track of games and players in them, it is safe for concurrent use. Players are tracked by their
+// connections and not by IDs they send, so that no one can act on behalf of another player
+type GameRegistry struct {
+	lock sync.RWMutex
+
+	games      map[uuid.UUID]*MultiplayerGame
+	players    map[*Player]*MultiplayerGame
+	spectators map[*Player]*MultiplayerGame
+	codes      map[string]*MultiplayerGame
+	tokens     map[string]*MultiplayerGame
+}
+
+func NewGameRegistry() *GameRegistry {
+	return &GameRegistry{
+		games:      map[uuid.UUID]*MultiplayerGame{},
+		players:    map[*Player]*MultiplayerGame{},
+		spectators: map[*Player]*MultiplayerGame{},
+		codes:      map[string]*MultiplayerGame{},
+		tokens:     map[string]*MultiplayerGame{},
+	}
+}
+
+// Create registers new game hosted by player, private games get a unique join code
+func (r *GameRegistry) Create(host *Player, hostName string, private bool, ruleSet rules.RuleSet) (
+	*MultiplayerGame, error,
+) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.inGame(host) {
+		return nil, ErrAlreadyInGame
+	}
+
+	game := NewMultiplayerGame(host, hostName, private, ruleSet)
+	if private {
+		for {
+			code, err := newJoinCode()
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := r.codes[code]; !ok {
+				game.code = code
+				break
+			}
+		}
+		r.codes[game.code] = game
+	}
+
+	host.token = uuid.NewString()
+
+	r.games[game.id] = game
+	r.players[host] = game
+	r.tokens[host.token] = game
+
+	return game, nil
+}
+
+// Join adds player to the game found by ID or by code
+func (r *GameRegistry) Join(player *Player, joinGame *api.JoinGame) (*MultiplayerGame, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.inGame(player) {
+		return nil, ErrAlreadyInGame
+	}
+
+	var game *MultiplayerGame
+	if joinGame.GetCode() != "" {
+		game = r.codes[NormalizeJoinCode(joinGame.GetCode())]
+	} else {
+		gameID, err := events.UUIDFromGRPC(joinGame.GetGameId())
+		if err != nil {
+			return nil, err
+		}
+
+		// Private games can be joined only by code
+		game = r.games[gameID]
+		if game != nil && game.private {
+			game = nil
+		}
+	}
+
+	if game == nil {
+		return nil, ErrGameNotFound
+	}
+
+	player.token = uuid.NewString()
+	if err := game.join(player); err != nil {
+		return nil, err
+	}
+
+	r.players[player] = game
+	r.tokens[player.token] = game
+	delete(r.codes, game.code)
+
+	return game, nil
+}
+
+// Resume returns player to the seat in the game that session token belongs to
+func (r *GameRegistry) Resume(player *Player, token string) (*MultiplayerGame, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.inGame(player) {
+		return nil, ErrAlreadyInGame
+	}
+
+	game, ok := r.tokens[token]
+	if !ok {
+		return nil, ErrGameNotFound
+	}
+
+	seat, err := game.resume(player, token)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(r.players, seat)
+	r.players[player] = game
+
+	return game, nil
+}
+
+func (r *GameRegistry) ByPlayer(player *Player) (*MultiplayerGame, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	game, ok := r.players[player]
+	return game, ok
+}
+
+// Spectate adds player as spectator of public game
+func (r *GameRegistry) Spectate(player *Player, gameID uuid.UUID, hideFleets bool) (*MultiplayerGame, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.inGame(player) {
+		return nil, ErrAlreadyInGame
+	}
+
+	game := r.games[gameID]
+	if game == nil || game.private {
+		return nil, ErrGameNotFound
+	}
+
+	if err := game.spectate(player, hideFleets); err != nil {
+		return nil, err
+	}
+
+	r.spectators[player] = game
+	return game, nil
+}
+
+// Unspectate removes spectator from the game it watches
+func (r *GameRegistry) Unspectate(player *Player) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	game, ok := r.spectators[player]
+	if !ok {
+		return
+	}
+
+	game.unspectate(player)
+	delete(r.spectators, player)
+}
+
+func (r *GameRegistry) Spectating(player *Player) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	_, ok := r.spectators[player]
+	return ok
+}
+
+// Running returns public games that are being played, oldest first
+func (r *GameRegistry) Running() []*MultiplayerGame {
+	return r.filter(func(game *MultiplayerGame) bool {
+		state := game.State()
+		return !game.private && (state == GameStatePlacing || state == GameStatePlaying)
+	})
+}
+
+// Open returns public games waiting for opponent, oldest first, games of disconnected hosts are not listed while
+// hosts may still resume them
+func (r *GameRegistry) Open() []*MultiplayerGame {
+	return r.filter(func(game *MultiplayerGame) bool {
+		return !game.private && game.State() == GameStateWaiting && game.Connected()
+	})
+}
+
+// filter returns games matching predicate, oldest first
+func (r *GameRegistry) filter(match func(game *MultiplayerGame) bool) []*MultiplayerGame {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	games := make([]*MultiplayerGame, 0, len(r.games))
+	for _, game := range r.games {
+		if match(game) {
+			games = append(games, game)
+		}
+	}
+
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].createdAt.Before(games[j].createdAt)
+	})
+
+	return games
+}
+
+// Remove unregisters game and all its players
+func (r *GameRegistry) Remove(game *MultiplayerGame) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.games[game.id] != game {
+		return
+	}
+
+	delete(r.games, game.id)
+	delete(r.codes, game.code)
+	for player, playerGame := range r.players {
+		if playerGame == game {
+			delete(r.players, player)
+		}
+	}
+	for token, tokenGame := range r.tokens {
+		if tokenGame == game {
+			delete(r.tokens, token)
+		}
+	}
+	for spectator, spectatorGame := range r.spectators {
+		if spectatorGame == game {
+			delete(r.spectators, spectator)
+		}
+	}
+}
+
+// inGame reports whether player plays or watches any game, must be called with lock held
+func (r *GameRegistry) inGame(player *Player) bool {
+	_, playing := r.players[player]
+	_, watching := r.spectators[player]
+	return playing || watching
+}
+
+func (r *GameRegistry) Len() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return len(r.games)
+}
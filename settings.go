@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/mymmrac/battleship/ai"
+	"github.com/mymmrac/battleship/client"
+	"github.com/mymmrac/battleship/cmd/server"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server/api"
+	"github.com/mymmrac/battleship/ui"
+)
+
+const (
+	settingsFileName = "settings.json"
+
+	defaultServerAddr = "127.0.0.1"
+
+	maxPlayerNameLen = 24
+
+	connectionTestTimeout = 5 * time.Second
+
+	// windowSaveDelay is a number of ticks window geometry should stay the same before it's saved, so that settings
+	// are not written on every tick while window is moved or resized
+	windowSaveDelay = 60
+)
+
+// Settings are client settings stored between launches
+type Settings struct {
+	ServerAddr string `json:"server_addr"`
+	ServerPort string `json:"server_port"`
+	PlayerName string `json:"player_name"`
+
+	Window     WindowSettings `json:"window"`
+	Fullscreen bool           `json:"fullscreen"`
+	Debug      bool           `json:"debug"`
+	Theme      string         `json:"theme"`
+
+	Difficulty string        `json:"difficulty"`
+	RuleSet    rules.RuleSet `json:"rule_set"`
+}
+
+// WindowSettings is a window geometry, zero size means that default geometry is used
+type WindowSettings struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+func defaultSettings() Settings {
+	return Settings{
+		ServerAddr: defaultServerAddr,
+		ServerPort: server.DefaultGRPCPort,
+		PlayerName: defaultPlayerName(),
+		Theme:      ui.DefaultTheme.Name,
+		Difficulty: ai.DefaultDifficulty.String(),
+		RuleSet:    rules.DefaultRuleSet,
+	}
+}
+
+func settingsPath() (string, error) {
+	dir, err := appConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, settingsFileName), nil
+}
+
+// loadSettings reads stored settings, defaults are used for missing settings
+func loadSettings() (Settings, error) {
+	path, err := settingsPath()
+	if err != nil {
+		return defaultSettings(), err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return defaultSettings(), nil
+		}
+		return defaultSettings(), err
+	}
+
+	// Settings are not decoded over defaults, since fleet of default rule set would be overwritten in place
+	var settings Settings
+	if err = json.Unmarshal(content, &settings); err != nil {
+		return defaultSettings(), fmt.Errorf("settings: %w", err)
+	}
+
+	return settings.withDefaults(), nil
+}
+
+// withDefaults replaces missing settings or ones that can't be used, for example edited by hand, with defaults
+func (s Settings) withDefaults() Settings {
+	defaults := defaultSettings()
+
+	if validateServerEndpoint(s.ServerAddr, s.ServerPort) != nil {
+		s.ServerAddr, s.ServerPort = defaults.ServerAddr, defaults.ServerPort
+	}
+	if validatePlayerName(s.PlayerName) != nil {
+		s.PlayerName = defaults.PlayerName
+	}
+	if s.Window.Width <= 0 || s.Window.Height <= 0 {
+		s.Window = defaults.Window
+	}
+	if _, ok := ui.ThemeByName(s.Theme); !ok {
+		s.Theme = defaults.Theme
+	}
+	if _, err := ai.ParseDifficulty(s.Difficulty); err != nil {
+		s.Difficulty = defaults.Difficulty
+	}
+	if predefined, ok := rules.RuleSetByName(s.RuleSet.Name); ok {
+		s.RuleSet = predefined
+	} else if s.RuleSet.Validate() != nil {
+		s.RuleSet = defaults.RuleSet
+	}
+
+	return s
+}
+
+func (s Settings) difficulty() ai.Difficulty {
+	difficulty, err := ai.ParseDifficulty(s.Difficulty)
+	if err != nil {
+		return ai.DefaultDifficulty
+	}
+	return difficulty
+}
+
+func (s Settings) theme() ui.Theme {
+	theme, ok := ui.ThemeByName(s.Theme)
+	if !ok {
+		return ui.DefaultTheme
+	}
+	return theme
+}
+
+// defaultWindow returns geometry of base size window in the center of the screen
+func defaultWindow() WindowSettings {
+	deviceScale := ebiten.DeviceScaleFactor()
+	windowWidth := baseWindowWidth * deviceScale
+	windowHeight := baseWindowHeight * deviceScale
+
+	screenWidth, screenHeight := ebiten.ScreenSizeInFullscreen()
+	return WindowSettings{
+		X:      int(math.Ceil((float64(screenWidth) - windowWidth) / 2.0)),
+		Y:      int(math.Ceil((float64(screenHeight) - windowHeight) / 2.0)),
+		Width:  int(math.Ceil(windowWidth)),
+		Height: int(math.Ceil(windowHeight)),
+	}
+}
+
+// onScreen reports whether window title is visible, stored geometry may be off-screen if screen was changed
+func (w WindowSettings) onScreen() bool {
+	if w.Width <= 0 || w.Height <= 0 {
+		return false
+	}
+
+	screenWidth, screenHeight := ebiten.ScreenSizeInFullscreen()
+	return w.X+w.Width > 0 && w.X < screenWidth && w.Y >= 0 && w.Y < screenHeight
+}
+
+func saveSettings(settings Settings) error {
+	path, err := settingsPath()
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o644)
+}
+
+// storeSettings saves settings after they were changed, errors are only reported since game can go on without them
+func (g *Game) storeSettings() {
+	if err := saveSettings(g.settings); err != nil {
+		fmt.Println(err) // TODO: Fix me
+	}
+}
+
+// updateWindowSettings stores window geometry after window was moved or resized
+func (g *Game) updateWindowSettings() {
+	// Geometry of the window is kept while in fullscreen
+	if ebiten.IsFullscreen() {
+		return
+	}
+
+	x, y := ebiten.WindowPosition()
+	width, height := ebiten.WindowSize()
+	window := WindowSettings{X: x, Y: y, Width: width, Height: height}
+
+	if window != g.window {
+		g.window = window
+		g.windowSaveTicks = windowSaveDelay
+		return
+	}
+
+	if g.windowSaveTicks == 0 {
+		return
+	}
+
+	g.windowSaveTicks--
+	if g.windowSaveTicks == 0 && g.settings.Window != window {
+		g.settings.Window = window
+		g.storeSettings()
+	}
+}
+
+func themeText(theme ui.Theme) string {
+	return "Theme: " + theme.Name
+}
+
+// nextTheme returns theme that goes after the current one, after the last goes the first one
+func nextTheme(theme ui.Theme) ui.Theme {
+	for i, t := range ui.Themes {
+		if t.Name == theme.Name {
+			return ui.Themes[(i+1)%len(ui.Themes)]
+		}
+	}
+
+	return ui.DefaultTheme
+}
+
+// validateServerEndpoint checks that server address and port can be dialed
+func validateServerEndpoint(addr, port string) error {
+	if addr == "" || strings.ContainsAny(addr, " /") {
+		return fmt.Errorf("bad server address: %q", addr)
+	}
+
+	portNumber, err := strconv.Atoi(port)
+	if err != nil || portNumber < 1 || portNumber > 65535 {
+		return fmt.Errorf("bad server port: %q", port)
+	}
+
+	return nil
+}
+
+func validatePlayerName(name string) error {
+	if name == "" || len([]rune(name)) > maxPlayerNameLen {
+		return fmt.Errorf("player name should be 1 to %d characters long", maxPlayerNameLen)
+	}
+
+	return nil
+}
+
+func serverTarget(addr, port string) string {
+	return net.JoinHostPort(addr, port)
+}
+
+func portFilter(r rune) (rune, bool) {
+	return r, '0' <= r && r <= '9'
+}
+
+// connectionTestResult is a result of server connection test, err is nil if server responded
+type connectionTestResult struct {
+	latency time.Duration
+	err     error
+}
+
+// testConnection connects to the server and measures its latency, result is sent to the results channel
+func testConnection(addr, port string, results chan<- connectionTestResult) {
+	ctx, cancel := context.WithTimeout(context.Background(), connectionTestTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, serverTarget(addr, port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		results <- connectionTestResult{err: err}
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	latency, err := client.Ping(ctx, api.NewEventManagerClient(conn))
+	results <- connectionTestResult{latency: latency, err: err}
+}
+
+// connectionErrorText returns short description of connection error, so that it fits the screen
+func connectionErrorText(err error) string {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return "server unavailable"
+	case codes.DeadlineExceeded:
+		return "timed out"
+	default:
+		return status.Convert(err).Message()
+	}
+}
+
+// settingsEndpoint returns server address and port entered on settings screen
+func (g *Game) settingsEndpoint() (string, string, error) {
+	addr := strings.TrimSpace(g.settingsAddrInput.Text())
+	port := strings.TrimSpace(g.settingsPortInput.Text())
+
+	return addr, port, validateServerEndpoint(addr, port)
+}
+
+// updateSettings handles changes made on settings screen, theme is saved right away and other settings on save
+func (g *Game) updateSettings() {
+	if g.settingsAddrInput.Changed() || g.settingsPortInput.Changed() || g.settingsNameInput.Changed() {
+		g.settingsStatusLabel.SetText("")
+	}
+
+	submitted := g.settingsAddrInput.Submitted() || g.settingsPortInput.Submitted() ||
+		g.settingsNameInput.Submitted()
+
+	switch {
+	case g.settingsTestBtn.Clicked():
+		addr, port, err := g.settingsEndpoint()
+		if err != nil {
+			g.settingsStatusLabel.SetText(err.Error())
+			return
+		}
+
+		// Results of previous tests are ignored, their channel is not read anymore
+		g.settingsTest = make(chan connectionTestResult, 1)
+		go testConnection(addr, port, g.settingsTest)
+
+		g.settingsTestBtn.Disable()
+		g.settingsStatusLabel.SetText("Connecting to " + serverTarget(addr, port) + "...")
+	case g.settingsThemeBtn.Clicked():
+		theme := nextTheme(g.settings.theme())
+		ui.ApplyTheme(theme)
+		g.settingsThemeBtn.SetText(themeText(theme))
+
+		g.settings.Theme = theme.Name
+		g.storeSettings()
+	case g.settingsSaveBtn.Clicked() || submitted:
+		addr, port, err := g.settingsEndpoint()
+		if err != nil {
+			g.settingsStatusLabel.SetText(err.Error())
+			return
+		}
+
+		name := strings.TrimSpace(g.settingsNameInput.Text())
+		if err = validatePlayerName(name); err != nil {
+			g.settingsStatusLabel.SetText(err.Error())
+			return
+		}
+
+		g.serverAddr, g.serverPort, g.playerName = addr, port, name
+		g.settings.ServerAddr, g.settings.ServerPort, g.settings.PlayerName = addr, port, name
+		if err = saveSettings(g.settings); err != nil {
+			g.settingsStatusLabel.SetText("Failed to save: " + err.Error())
+			return
+		}
+
+		g.settingsStatusLabel.SetText("Saved, " + serverTarget(addr, port) + " will be used")
+	}
+
+	select {
+	case result := <-g.settingsTest:
+		g.settingsTest = nil
+		g.settingsTestBtn.Enable()
+
+		if result.err != nil {
+			g.settingsStatusLabel.SetText("Connection failed: " + connectionErrorText(result.err))
+			return
+		}
+
+		g.settingsStatusLabel.SetText(fmt.Sprintf("Connected, latency %d ms", result.latency.Milliseconds()))
+	default:
+	}
+}
@@ -1,86 +1,122 @@
 package main
 
 import (
-	"math"
+	"image/color"
 	"strconv"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
-	"golang.org/x/image/font"
 
 	"github.com/mymmrac/battleship/core"
 	"github.com/mymmrac/battleship/data"
 	"github.com/mymmrac/battleship/ui"
 )
 
-var allowedShips = []int{
-	4, // 1
-	3, // 2
-	2, // 3
-	1, // 4
-}
-
-// var allowedShips = []int{ // TODO: Remove
-// 	1, // 1
-// 	1, // 2
-// 	1, // 3
-// 	0, // 4
-// 	0, // 5
-// }
-
 const shipyardBorder = false
 const maxShipyardRowLen = 12 // 24
 
+// shipyardHeight is a max height of the shipyard, cells are scaled down if ships don't fit
+const shipyardHeight = 3 * maxCellSize
+
 type Shipyard struct {
 	core.BaseGameObject
 
-	pos      data.Point[float32]
-	board    *Board
-	fontFace font.Face
-	ships    []int
+	pos   data.Point[float32]
+	board *Board
+	faces CellFaces
+
+	// hoverEntry is an index of hovered entry with ships left to place, or -1
+	hoverEntry int
+	hoverCell  int
 }
 
-func NewShipyard(pos data.Point[float32], board *Board, fontFace font.Face) *Shipyard {
+// shipyardEntry is a position of ships count cell, ship cells follow it in the same row
+type shipyardEntry struct {
+	length   int
+	col, row int
+}
+
+func NewShipyard(pos data.Point[float32], board *Board, faces CellFaces) *Shipyard {
 	return &Shipyard{
 		BaseGameObject: core.NewBaseGameObject(),
 		pos:            pos,
 		board:          board,
-		fontFace:       fontFace,
-		ships:          make([]int, len(allowedShips)),
+		faces:          faces,
+		hoverEntry:     -1,
+	}
+}
+
+// Update starts dragging a ship to the board when player presses on one with ships of its length left to place
+func (s *Shipyard) Update(cp data.Point[float32]) {
+	s.hoverEntry = -1
+
+	entries, rowLen := s.layout()
+	if len(entries) == 0 || s.board.Dragging() {
+		return
+	}
+
+	cellSize := s.cellSize(entries, rowLen)
+	fleet := s.board.model.Fleet()
+	ships := s.board.model.ShipsCount()
+	for i, entry := range entries {
+		if fleet[entry.length-1] <= ships[entry.length-1] {
+			continue
+		}
+
+		start := s.cellPos(entry.col+1, entry.row, cellSize)
+		if cp.X < start.X || cp.Y < start.Y || cp.Y >= start.Y+cellSize ||
+			cp.X >= start.X+float32(entry.length)*cellSize {
+			continue
+		}
+
+		s.hoverEntry = i
+		s.hoverCell = int((cp.X - start.X) / cellSize)
+	}
+
+	if s.hoverEntry >= 0 && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		s.board.StartDrag(entries[s.hoverEntry].length, s.hoverCell)
 	}
 }
 
-func (s *Shipyard) Update(_ data.Point[float32]) {
-	s.ships = s.shipsCount()
+func (s *Shipyard) CursorPointer() bool {
+	return s.hoverEntry >= 0
+}
+
+func (s *Shipyard) Disable() {
+	s.hoverEntry = -1
+	s.BaseGameObject.Disable()
 }
 
 func (s *Shipyard) Draw(screen *ebiten.Image) {
-	longestShip := len(allowedShips)
+	fleet := s.board.model.Fleet()
+	ships := s.board.model.ShipsCount()
+	entries, rowLen := s.layout()
+	if len(entries) == 0 {
+		return
+	}
+
+	rows := entries[len(entries)-1].row + 1
+	cellSize := s.cellSize(entries, rowLen)
+	cellPadding := cellSize * cellPaddingRatio
+	innerCellSize := cellSize - cellPadding
 
 	// Border
 	if shipyardBorder {
-		size := longestShip*(longestShip+1)/2 + longestShip*2 - 1
-		rows := float32(math.Ceil(float64(size)/maxShipyardRowLen))*2 - 1
 		vector.StrokeRect(
 			screen,
-			s.pos.X-cellPaddingSize,
-			s.pos.Y-cellPaddingSize,
-			(maxShipyardRowLen)*cellSize+cellPaddingSize*2,
-			(rows)*cellSize+cellPaddingSize*2,
+			s.pos.X-cellPadding,
+			s.pos.Y-cellPadding,
+			float32(rowLen)*cellSize+cellPadding*2,
+			float32(rows)*cellSize+cellPadding*2,
 			2,
 			ui.BorderColor,
 		)
 	}
 
-	// Count cells
-	row, col := 0, 0
-	for y := 0; y < longestShip; y++ {
-		if col+y+1+1 > maxShipyardRowLen {
-			col = 0
-			row += 2
-		}
-
-		pos := s.innerCellPos(col, row)
+	for i, entry := range entries {
+		// Count cell
+		pos := s.innerCellPos(entry.col, entry.row, cellSize)
 		vector.DrawFilledRect(
 			screen,
 			pos.X,
@@ -90,130 +126,105 @@ func (s *Shipyard) Draw(screen *ebiten.Image) {
 			ui.MutedColor,
 		)
 
-		col += y + 1 + 2
-		if col > maxShipyardRowLen {
-			col = 0
-			row += 2
-		}
-	}
-
-	// Count cells text
-	row, col = 0, 0
-	for y := 0; y < longestShip; y++ {
-		if col+y+1+1 > maxShipyardRowLen {
-			col = 0
-			row += 2
-		}
-
-		pos := s.cellPos(col, row)
+		// Count cell text
+		pos = s.cellPos(entry.col, entry.row, cellSize)
 		ui.DrawCenteredText(
 			screen,
-			s.fontFace,
-			strconv.Itoa(allowedShips[y]-s.ships[y]),
+			s.faces.For(cellSize),
+			strconv.Itoa(fleet[entry.length-1]-ships[entry.length-1]),
 			int(pos.X+cellSize/2),
 			int(pos.Y+cellSize/2),
 			ui.TextDarkColor,
 		)
 
-		col += y + 1 + 2
-		if col > maxShipyardRowLen {
-			col = 0
-			row += 2
+		// Ship cells
+		var clr color.Color = ui.ShipColor
+		if fleet[entry.length-1] <= ships[entry.length-1] {
+			clr = ui.MutedColor
 		}
-	}
-
-	// Ship cells
-	row, col = 0, 0
-	for y := 0; y < longestShip; y++ {
-		if col+y+1+1 > maxShipyardRowLen {
-			col = 0
-			row += 2
-		}
-
-		for x := 0; x < y+1; x++ {
-			pos := s.innerCellPos(col+1, row)
+		for x := 0; x < entry.length; x++ {
+			pos = s.innerCellPos(entry.col+1+x, entry.row, cellSize)
 			vector.DrawFilledRect(
 				screen,
 				pos.X,
 				pos.Y,
 				innerCellSize,
 				innerCellSize,
-				ui.ShipColor,
+				clr,
 			)
+		}
 
-			col++
-			if col > maxShipyardRowLen {
-				col = 0
-				row += 2
-			}
+		if i == s.hoverEntry {
+			pos = s.cellPos(entry.col+1, entry.row, cellSize)
+			vector.StrokeRect(
+				screen,
+				pos.X,
+				pos.Y,
+				float32(entry.length)*cellSize,
+				cellSize,
+				cellPadding,
+				ui.HighlightColor,
+			)
 		}
+	}
+
+	// Drawn last to stay on top of the shipyard
+	s.board.drawDragOutside(screen)
+}
 
-		col += 2
-		if col > maxShipyardRowLen {
+// cellSize returns size of shipyard cells, so that all entries fit into the shipyard
+func (s *Shipyard) cellSize(entries []shipyardEntry, rowLen int) float32 {
+	rows := entries[len(entries)-1].row + 1
+	return fitCellSize(maxShipyardRowLen*maxCellSize, shipyardHeight, rowLen, rows)
+}
+
+// layout places ships of each length that fleet has into rows, ship is never split between rows, row is extended
+// if the longest ship doesn't fit into it
+func (s *Shipyard) layout() ([]shipyardEntry, int) {
+	fleet := s.board.model.Fleet()
+
+	rowLen := maxShipyardRowLen
+	if len(fleet)+1 > rowLen {
+		rowLen = len(fleet) + 1
+	}
+
+	var entries []shipyardEntry
+	row, col := 0, 0
+	for i, count := range fleet {
+		length := i + 1
+		if count == 0 {
+			continue
+		}
+
+		// Count cell and ship cells
+		width := 1 + length
+		if col > 0 && col+width > rowLen {
 			col = 0
 			row += 2
 		}
+
+		entries = append(entries, shipyardEntry{length: length, col: col, row: row})
+		col += width + 1
 	}
+
+	return entries, rowLen
 }
 
-func (s *Shipyard) innerCellPos(x, y int) data.Point[float32] {
+func (s *Shipyard) innerCellPos(x, y int, cellSize float32) data.Point[float32] {
+	cellPadding := cellSize * cellPaddingRatio
 	return data.NewPoint(
-		s.pos.X+float32(x)*cellSize+cellPaddingSize/2,
-		s.pos.Y+float32(y)*cellSize+cellPaddingSize/2,
+		s.pos.X+float32(x)*cellSize+cellPadding/2,
+		s.pos.Y+float32(y)*cellSize+cellPadding/2,
 	)
 }
 
-func (s *Shipyard) cellPos(x, y int) data.Point[float32] {
+func (s *Shipyard) cellPos(x, y int, cellSize float32) data.Point[float32] {
 	return data.NewPoint(
 		s.pos.X+float32(x)*cellSize,
 		s.pos.Y+float32(y)*cellSize,
 	)
 }
 
-func (s *Shipyard) shipsCount() []int {
-	ships := make([]int, len(allowedShips))
-	visited := [cellsCount][cellsCount]bool{}
-
-	for y := 0; y < cellsCount; y++ {
-		for x := 0; x < cellsCount; x++ {
-			if visited[y][x] {
-				continue
-			}
-			visited[y][x] = true
-
-			if s.board.At(x, y) == CellShip {
-				l := 1
-
-				dx := x + 1
-				for dx < cellsCount && s.board.At(dx, y) == CellShip {
-					visited[y][dx] = true
-					l++
-					dx++
-				}
-
-				if l == 1 {
-					dy := y + 1
-					for dy < cellsCount && s.board.At(x, dy) == CellShip {
-						visited[dy][x] = true
-						l++
-						dy++
-					}
-				}
-
-				ships[l-1]++
-			}
-		}
-	}
-
-	return ships
-}
-
 func (s *Shipyard) ready() bool {
-	for i, count := range s.ships {
-		if allowedShips[i]-count != 0 {
-			return false
-		}
-	}
-
-	return true
+	return s.board.model.Validate() == nil
 }
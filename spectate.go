@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mymmrac/battleship/events"
+	"github.com/mymmrac/battleship/rules"
+	"github.com/mymmrac/battleship/server/api"
+)
+
+const spectateGuestName = "Guest"
+
+// startSpectating prepares boards of watched game from the first snapshot received after joining as spectator
+func (g *Game) startSpectating(snapshot *api.SpectatorSnapshot) error {
+	ruleSet, err := events.RuleSetFromGRPC(snapshot.GetRules())
+	if err != nil {
+		return err
+	}
+
+	g.setupBoards(ruleSet)
+	g.spectateHostName = snapshot.GetHostName()
+	if g.spectateHostName == "" {
+		g.spectateHostName = "Host"
+	}
+	g.spectateLeaving = false
+	g.disconnected = false
+
+	return g.applySpectatorSnapshot(snapshot)
+}
+
+// applySpectatorSnapshot shows state of watched game, host's board is on the left and guest's on the right
+func (g *Game) applySpectatorSnapshot(snapshot *api.SpectatorSnapshot) error {
+	if err := events.BoardFromGRPC(snapshot.GetHostBoard(), g.myBoard.model); err != nil {
+		return err
+	}
+	if err := events.BoardFromGRPC(snapshot.GetGuestBoard(), g.opponentBoard.model); err != nil {
+		return err
+	}
+
+	g.spectateInfoLabel.SetText(g.spectateHostName + " vs " + spectateGuestName)
+
+	switch snapshot.GetState() {
+	case api.GameState_GAME_STATE_PLACING:
+		g.playerTurnLabel.SetText("Placing Ships")
+	case api.GameState_GAME_STATE_PLAYING:
+		g.setSpectateTurn(snapshot.GetHostTurn())
+	case api.GameState_GAME_STATE_FINISHED:
+		// Fleets are revealed when game ends, so the winner is the one with ships left
+		hostAlive, guestAlive := g.myBoard.model.HasAlive(), g.opponentBoard.model.HasAlive()
+		switch {
+		case hostAlive && !guestAlive:
+			g.playerTurnLabel.SetText(g.spectateHostName + " Won!")
+		case guestAlive && !hostAlive:
+			g.playerTurnLabel.SetText(spectateGuestName + " Won!")
+		default:
+			g.playerTurnLabel.SetText("Player Left!")
+		}
+	default:
+		return fmt.Errorf("unexpected game state: %s", snapshot.GetState())
+	}
+
+	return nil
+}
+
+func (g *Game) setSpectateTurn(hostTurn bool) {
+	g.spectateHostTurn = hostTurn
+	if hostTurn {
+		g.playerTurnLabel.SetText(g.spectateHostName + "'s Turn")
+	} else {
+		g.playerTurnLabel.SetText(spectateGuestName + "'s Turn")
+	}
+}
+
+// markSpectatedShot marks shot on the board it was fired at, shots of the guest are marked as made by opponent
+func (g *Game) markSpectatedShot(shotResult *api.ShotResult) (rules.ShotResult, error) {
+	result, err := events.ShotResultFromGRPC(shotResult.GetOutcome())
+	if err != nil {
+		return 0, err
+	}
+
+	board := g.opponentBoard
+	if shotResult.GetByOpponent() {
+		board = g.myBoard
+	}
+
+	pos := events.PointFromGRPC(shotResult.GetPos())
+	if board.model.InBounds(pos) {
+		board.model.Mark(pos, result)
+	}
+
+	return result, nil
+}
+
+// stopSpectating closes connection to the server, game events handler ends with disconnected event after that
+func (g *Game) stopSpectating() {
+	if err := g.eventManager.Close(); err != nil {
+		fmt.Println(err) // TODO: Fix me
+	}
+}
+
+// closeConnection closes connection to the server after game events are no longer handled
+func (g *Game) closeConnection() {
+	if g.grpcConn == nil {
+		return
+	}
+
+	if err := g.grpcConn.Close(); err != nil {
+		fmt.Println(err) // TODO: Fix me
+	}
+	g.grpcConn = nil
+}
+
+func fleetsText(hideFleets bool) string {
+	if hideFleets {
+		return "Fleets: hidden"
+	}
+	return "Fleets: shown"
+}
@@ -43,6 +43,10 @@ func (b *Button) Update(cp data.Point[float32]) {
 	b.clicked = b.hover && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
 }
 
+func (b *Button) SetText(text string) {
+	b.text = text
+}
+
 func (b *Button) Disable() {
 	b.hover = false
 	b.clicked = false
@@ -8,33 +8,21 @@ var MutedColor = color.Gray16{Y: 0xaaff}
 
 var BorderColor = color.White
 
-var HighlightColor = color.RGBA{
-	R: 236,
-	G: 168,
-	B: 105,
-	A: 255,
-}
-
-var ShipColor = color.RGBA{
-	R: 83,
-	G: 127,
-	B: 231,
-	A: 255,
-}
-
-var MissColor = color.RGBA{
-	R: 60,
-	G: 64,
-	B: 72,
-	A: 255,
-}
-
-var ShipHitColor = color.RGBA{
-	R: 245,
-	G: 80,
-	B: 80,
-	A: 255,
-}
+// Accent colors are changed by ApplyTheme
+
+var HighlightColor = DefaultTheme.Highlight
+
+var SelectionColor = DefaultTheme.Selection
+
+var ShipColor = DefaultTheme.Ship
+
+var MissColor = DefaultTheme.Miss
+
+var ShipHitColor = DefaultTheme.ShipHit
+
+var ValidColor = DefaultTheme.Valid
+
+var InvalidColor = DefaultTheme.Invalid
 
 var TextDarkColor = color.Black
 var TextLightColor = color.White
@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+
+	"github.com/mymmrac/battleship/core"
+	"github.com/mymmrac/battleship/data"
+)
+
+const (
+	listPadding        float32 = 4
+	listTextPadding    float32 = 12
+	listScrollBarWidth float32 = 6
+)
+
+// List is a scrollable list of text items with single selection, it can be controlled by mouse (click to select,
+// double click to activate, wheel to scroll) or keyboard (arrows to select, enter to activate)
+type List struct {
+	core.BaseGameObject
+
+	pos        data.Point[float32]
+	width      float32
+	height     float32
+	itemHeight float32
+	fontFace   font.Face
+
+	items     []string
+	emptyText string
+
+	selected  int
+	scroll    int
+	hover     int
+	activated bool
+
+	lastClickTicks int
+	ticks          int
+}
+
+func NewList(pos data.Point[float32], width, height, itemHeight float32, fontFace font.Face) *List {
+	return &List{
+		BaseGameObject: core.NewBaseGameObject(),
+		pos:            pos,
+		width:          width,
+		height:         height,
+		itemHeight:     itemHeight,
+		fontFace:       fontFace,
+		selected:       -1,
+		hover:          -1,
+	}
+}
+
+// SetItems replaces list items, selection is kept if it is still in range
+func (l *List) SetItems(items []string) {
+	l.items = items
+
+	if l.selected >= len(items) {
+		l.selected = len(items) - 1
+	}
+	l.clampScroll()
+}
+
+func (l *List) SetEmptyText(text string) {
+	l.emptyText = text
+}
+
+// Selected returns index of selected item or -1 if nothing selected
+func (l *List) Selected() int {
+	return l.selected
+}
+
+func (l *List) SetSelected(index int) {
+	if index < -1 || index >= len(l.items) {
+		index = -1
+	}
+
+	l.selected = index
+	l.scrollToSelected()
+}
+
+// Activated reports whether selected item was double-clicked or confirmed with enter during last update
+func (l *List) Activated() bool {
+	return l.activated
+}
+
+func (l *List) Update(cp data.Point[float32]) {
+	l.ticks++
+	l.activated = false
+	l.hover = -1
+
+	inside := l.pos.X <= cp.X && cp.X <= l.pos.X+l.width &&
+		l.pos.Y <= cp.Y && cp.Y <= l.pos.Y+l.height
+	if inside {
+		index := l.scroll + int((cp.Y-l.pos.Y-listPadding)/l.itemHeight)
+		if cp.Y >= l.pos.Y+listPadding && index < len(l.items) && index < l.scroll+l.visibleCount() {
+			l.hover = index
+		}
+
+		_, wheelY := ebiten.Wheel()
+		if wheelY > 0 {
+			l.scroll--
+		} else if wheelY < 0 {
+			l.scroll++
+		}
+		l.clampScroll()
+	}
+
+	if l.hover != -1 && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if l.hover == l.selected && l.ticks-l.lastClickTicks <= ebiten.TPS()/2 {
+			l.activated = true
+		}
+
+		l.selected = l.hover
+		l.lastClickTicks = l.ticks
+	}
+
+	if len(l.items) == 0 {
+		return
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		if l.selected < len(l.items)-1 {
+			l.selected++
+		}
+		l.scrollToSelected()
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		if l.selected > 0 {
+			l.selected--
+		} else if l.selected == -1 {
+			l.selected = 0
+		}
+		l.scrollToSelected()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		l.activated = l.selected != -1
+	}
+}
+
+func (l *List) Disable() {
+	l.hover = -1
+	l.activated = false
+	l.BaseGameObject.Disable()
+}
+
+func (l *List) CursorPointer() bool {
+	return l.hover != -1
+}
+
+func (l *List) Draw(screen *ebiten.Image) {
+	// Border
+	clr := BorderColor
+	if !l.Active() {
+		clr = MutedColor
+	}
+	vector.StrokeRect(screen, l.pos.X, l.pos.Y, l.width, l.height, 2, clr)
+
+	if len(l.items) == 0 {
+		DrawCenteredText(screen, l.fontFace, l.emptyText,
+			int(l.pos.X+l.width/2), int(l.pos.Y+l.height/2), MutedColor)
+		return
+	}
+
+	// Items
+	visible := l.visibleCount()
+	for i := l.scroll; i < len(l.items) && i < l.scroll+visible; i++ {
+		y := l.pos.Y + listPadding + float32(i-l.scroll)*l.itemHeight
+
+		textClr := TextLightColor
+		switch {
+		case i == l.selected:
+			vector.DrawFilledRect(screen, l.pos.X+listPadding, y,
+				l.width-listPadding*3-listScrollBarWidth, l.itemHeight, HighlightColor)
+			textClr = TextDarkColor
+		case i == l.hover:
+			vector.DrawFilledRect(screen, l.pos.X+listPadding, y,
+				l.width-listPadding*3-listScrollBarWidth, l.itemHeight, MutedColor)
+			textClr = TextDarkColor
+		}
+
+		DrawLeftCenteredText(screen, l.fontFace, l.items[i],
+			int(l.pos.X+listTextPadding), int(y+l.itemHeight/2), textClr)
+	}
+
+	// Scroll bar
+	if len(l.items) > visible {
+		trackHeight := l.height - listPadding*2
+		barHeight := trackHeight * float32(visible) / float32(len(l.items))
+		barY := l.pos.Y + listPadding + trackHeight*float32(l.scroll)/float32(len(l.items))
+		vector.DrawFilledRect(screen, l.pos.X+l.width-listPadding-listScrollBarWidth, barY,
+			listScrollBarWidth, barHeight, MutedColor)
+	}
+}
+
+func (l *List) visibleCount() int {
+	return int((l.height - listPadding*2) / l.itemHeight)
+}
+
+func (l *List) clampScroll() {
+	maxScroll := len(l.items) - l.visibleCount()
+	if l.scroll > maxScroll {
+		l.scroll = maxScroll
+	}
+	if l.scroll < 0 {
+		l.scroll = 0
+	}
+}
+
+func (l *List) scrollToSelected() {
+	if l.selected == -1 {
+		return
+	}
+
+	if l.selected < l.scroll {
+		l.scroll = l.selected
+	} else if l.selected >= l.scroll+l.visibleCount() {
+		l.scroll = l.selected - l.visibleCount() + 1
+	}
+	l.clampScroll()
+}
@@ -0,0 +1,446 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/atotto/clipboard"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+
+	"github.com/mymmrac/battleship/core"
+	"github.com/mymmrac/battleship/data"
+)
+
+const (
+	textInputPadding     float32 = 12
+	textInputCursorWidth float32 = 2
+
+	// Held key is repeated after delay with interval, both in ticks
+	keyRepeatDelay    = 30
+	keyRepeatInterval = 3
+)
+
+// TextInput is a single line text field, it gets focus on click and loses it on click outside, while focused it can be
+// controlled by keyboard:
+//
+//	Left, Right, Home, End  move cursor, with Shift selection is extended
+//	Backspace, Delete       delete selection or character before or after cursor
+//	Ctrl+A                  select all
+//	Ctrl+C, Ctrl+X, Ctrl+V  copy, cut and paste using system clipboard
+//	Enter                   submit
+//
+// Text can also be selected by dragging mouse or by clicking with Shift
+type TextInput struct {
+	core.BaseGameObject
+
+	pos      data.Point[float32]
+	width    float32
+	height   float32
+	fontFace font.Face
+
+	text        []rune
+	maxLength   int
+	placeholder string
+	filter      func(r rune) (rune, bool)
+
+	// cursor is an index of rune before which cursor is, selection spans from anchor to cursor
+	cursor int
+	anchor int
+	// offset is an index of the first visible rune, text is scrolled to keep cursor visible
+	offset int
+
+	hover     bool
+	focused   bool
+	selecting bool
+	submitted bool
+	changed   bool
+	ticks     int
+}
+
+func NewTextInput(pos data.Point[float32], width, height float32, fontFace font.Face) *TextInput {
+	return &TextInput{
+		BaseGameObject: core.NewBaseGameObject(),
+		pos:            pos,
+		width:          width,
+		height:         height,
+		fontFace:       fontFace,
+	}
+}
+
+func (t *TextInput) Text() string {
+	return string(t.text)
+}
+
+// SetText replaces text and moves cursor to its end, text is filtered and cut to max length
+func (t *TextInput) SetText(text string) {
+	t.text = nil
+	t.cursor, t.anchor, t.offset = 0, 0, 0
+	t.insert(text)
+	t.changed = false
+	t.scrollToCursor()
+}
+
+func (t *TextInput) Clear() {
+	t.SetText("")
+}
+
+// SetMaxLength limits number of characters in text, zero means no limit
+func (t *TextInput) SetMaxLength(maxLength int) {
+	t.maxLength = maxLength
+	if maxLength > 0 && len(t.text) > maxLength {
+		t.text = t.text[:maxLength]
+		t.cursor, t.anchor = t.clamp(t.cursor), t.clamp(t.anchor)
+		t.scrollToCursor()
+	}
+}
+
+// SetPlaceholder sets text shown in muted color while input is empty and not focused
+func (t *TextInput) SetPlaceholder(placeholder string) {
+	t.placeholder = placeholder
+}
+
+// SetFilter sets function that maps typed or pasted characters, character is dropped if filter doesn't accept it
+func (t *TextInput) SetFilter(filter func(r rune) (rune, bool)) {
+	t.filter = filter
+}
+
+func (t *TextInput) Focused() bool {
+	return t.focused
+}
+
+func (t *TextInput) Focus() {
+	t.focused = true
+	t.ticks = 0
+}
+
+func (t *TextInput) Blur() {
+	t.focused = false
+	t.selecting = false
+	t.anchor = t.cursor
+}
+
+// Submitted reports whether enter was pressed while input was focused during last update
+func (t *TextInput) Submitted() bool {
+	return t.submitted
+}
+
+// Changed reports whether text was changed by user during last update
+func (t *TextInput) Changed() bool {
+	return t.changed
+}
+
+// Selection returns selected text
+func (t *TextInput) Selection() string {
+	start, end := t.selection()
+	return string(t.text[start:end])
+}
+
+func (t *TextInput) Update(cp data.Point[float32]) {
+	t.ticks++
+	t.submitted = false
+	t.changed = false
+
+	t.hover = t.pos.X <= cp.X && cp.X <= t.pos.X+t.width &&
+		t.pos.Y <= cp.Y && cp.Y <= t.pos.Y+t.height
+
+	t.updateMouse(cp)
+	if !t.focused {
+		return
+	}
+
+	t.updateKeyboard()
+	t.scrollToCursor()
+}
+
+func (t *TextInput) updateMouse(cp data.Point[float32]) {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if !t.hover {
+			t.Blur()
+			return
+		}
+
+		extend := t.focused && ebiten.IsKeyPressed(ebiten.KeyShift)
+		t.Focus()
+		t.moveCursor(t.indexAt(cp.X), extend)
+		t.selecting = true
+		return
+	}
+
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		t.selecting = false
+		return
+	}
+
+	if t.selecting {
+		t.moveCursor(t.indexAt(cp.X), true)
+	}
+}
+
+func (t *TextInput) updateKeyboard() {
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	ctrl := ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+
+	if ctrl {
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyA):
+			t.anchor, t.cursor = 0, len(t.text)
+		case inpututil.IsKeyJustPressed(ebiten.KeyC):
+			t.copySelection()
+		case inpututil.IsKeyJustPressed(ebiten.KeyX):
+			if t.copySelection() {
+				t.deleteSelection()
+			}
+		case inpututil.IsKeyJustPressed(ebiten.KeyV):
+			// Clipboard may be unavailable, in that case nothing is pasted
+			if text, err := clipboard.ReadAll(); err == nil {
+				t.insert(text)
+			}
+		}
+	} else if chars := ebiten.AppendInputChars(nil); len(chars) > 0 {
+		t.insert(string(chars))
+	}
+
+	switch {
+	case KeyRepeated(ebiten.KeyBackspace):
+		if !t.deleteSelection() && t.cursor > 0 {
+			t.delete(t.cursor-1, t.cursor)
+		}
+	case KeyRepeated(ebiten.KeyDelete):
+		if !t.deleteSelection() && t.cursor < len(t.text) {
+			t.delete(t.cursor, t.cursor+1)
+		}
+	case KeyRepeated(ebiten.KeyArrowLeft):
+		// Without shift cursor jumps to the start of selection
+		start, _ := t.selection()
+		if shift || start == t.cursor {
+			start = t.cursor - 1
+		}
+		t.moveCursor(start, shift)
+	case KeyRepeated(ebiten.KeyArrowRight):
+		_, end := t.selection()
+		if shift || end == t.cursor {
+			end = t.cursor + 1
+		}
+		t.moveCursor(end, shift)
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		t.moveCursor(0, shift)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		t.moveCursor(len(t.text), shift)
+	}
+
+	t.submitted = inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter)
+}
+
+func (t *TextInput) Disable() {
+	t.hover = false
+	t.submitted = false
+	t.changed = false
+	t.Blur()
+	t.BaseGameObject.Disable()
+}
+
+func (t *TextInput) DisableAndHide() {
+	t.Disable()
+	t.Hide()
+}
+
+func (t *TextInput) CursorPointer() bool {
+	return t.hover
+}
+
+func (t *TextInput) Draw(screen *ebiten.Image) {
+	// Border
+	var clr color.Color = BorderColor
+	switch {
+	case !t.Active():
+		clr = MutedColor
+	case t.focused:
+		clr = HighlightColor
+	}
+	vector.StrokeRect(screen, t.pos.X, t.pos.Y, t.width, t.height, 2, clr)
+
+	textX := t.pos.X + textInputPadding
+	centerY := int(t.pos.Y + t.height/2)
+
+	if len(t.text) == 0 && !t.focused {
+		DrawLeftCenteredText(screen, t.fontFace, t.placeholder, int(textX), centerY, MutedColor)
+		return
+	}
+
+	visibleEnd := t.visibleEnd()
+	lineHeight := float32(t.fontFace.Metrics().Height.Ceil())
+	lineY := t.pos.Y + (t.height-lineHeight)/2
+
+	// Selection
+	start, end := t.selection()
+	if t.focused && start != end {
+		start, end = t.clampVisible(start, visibleEnd), t.clampVisible(end, visibleEnd)
+		startX, endX := textX+t.widthBetween(t.offset, start), textX+t.widthBetween(t.offset, end)
+		vector.DrawFilledRect(screen, startX, lineY, endX-startX, lineHeight, SelectionColor)
+	}
+
+	// Text
+	clr = TextLightColor
+	if !t.Active() {
+		clr = MutedColor
+	}
+	DrawLeftCenteredText(screen, t.fontFace, string(t.text[t.offset:visibleEnd]), int(textX), centerY, clr)
+
+	// Cursor blinks twice per second and stays visible while typing
+	if t.focused && t.ticks%ebiten.TPS() < ebiten.TPS()/2 {
+		cursorX := textX + t.widthBetween(t.offset, t.cursor)
+		vector.DrawFilledRect(screen, cursorX, lineY, textInputCursorWidth, lineHeight, TextLightColor)
+	}
+}
+
+// insert replaces selection with filtered text, characters that exceed max length are dropped
+func (t *TextInput) insert(text string) {
+	t.deleteSelection()
+
+	var inserted []rune
+	for _, r := range text {
+		if r == '\n' || r == '\r' || r == '\t' {
+			r = ' '
+		}
+
+		if t.filter != nil {
+			var ok bool
+			if r, ok = t.filter(r); !ok {
+				continue
+			}
+		}
+
+		if t.maxLength > 0 && len(t.text)+len(inserted) >= t.maxLength {
+			break
+		}
+		inserted = append(inserted, r)
+	}
+
+	if len(inserted) == 0 {
+		return
+	}
+
+	t.text = append(t.text[:t.cursor], append(inserted, t.text[t.cursor:]...)...)
+	t.moveCursor(t.cursor+len(inserted), false)
+	t.changed = true
+}
+
+// delete removes text between start and end and moves cursor to start
+func (t *TextInput) delete(start, end int) {
+	t.text = append(t.text[:start], t.text[end:]...)
+	t.moveCursor(start, false)
+	t.changed = true
+}
+
+// deleteSelection removes selected text, returns false if nothing was selected
+func (t *TextInput) deleteSelection() bool {
+	start, end := t.selection()
+	if start == end {
+		return false
+	}
+
+	t.delete(start, end)
+	return true
+}
+
+// copySelection copies selected text to clipboard, returns false if nothing was copied
+func (t *TextInput) copySelection() bool {
+	selection := t.Selection()
+	if selection == "" {
+		return false
+	}
+
+	return clipboard.WriteAll(selection) == nil
+}
+
+// moveCursor moves cursor to index, selection is extended or collapsed
+func (t *TextInput) moveCursor(index int, extend bool) {
+	t.cursor = t.clamp(index)
+	if !extend {
+		t.anchor = t.cursor
+	}
+	t.ticks = 0
+}
+
+func (t *TextInput) selection() (int, int) {
+	if t.anchor < t.cursor {
+		return t.anchor, t.cursor
+	}
+	return t.cursor, t.anchor
+}
+
+func (t *TextInput) clamp(index int) int {
+	if index < 0 {
+		return 0
+	}
+	if index > len(t.text) {
+		return len(t.text)
+	}
+	return index
+}
+
+func (t *TextInput) clampVisible(index, visibleEnd int) int {
+	if index < t.offset {
+		return t.offset
+	}
+	if index > visibleEnd {
+		return visibleEnd
+	}
+	return index
+}
+
+// indexAt returns index of visible character boundary nearest to x
+func (t *TextInput) indexAt(x float32) int {
+	x -= t.pos.X + textInputPadding
+
+	visibleEnd := t.visibleEnd()
+	for i := t.offset; i < visibleEnd; i++ {
+		left, right := t.widthBetween(t.offset, i), t.widthBetween(t.offset, i+1)
+		if x < (left+right)/2 {
+			return i
+		}
+	}
+
+	return visibleEnd
+}
+
+// scrollToCursor updates offset, so that cursor is visible
+func (t *TextInput) scrollToCursor() {
+	if t.offset > t.cursor {
+		t.offset = t.cursor
+	}
+
+	for t.offset < t.cursor && t.widthBetween(t.offset, t.cursor) > t.maxTextWidth() {
+		t.offset++
+	}
+
+	// Hidden text is scrolled back if there is free space after the end
+	for t.offset > 0 && t.widthBetween(t.offset-1, len(t.text)) <= t.maxTextWidth() {
+		t.offset--
+	}
+}
+
+// visibleEnd returns index after the last character that fits after offset
+func (t *TextInput) visibleEnd() int {
+	end := t.offset
+	for end < len(t.text) && t.widthBetween(t.offset, end+1) <= t.maxTextWidth() {
+		end++
+	}
+	return end
+}
+
+func (t *TextInput) widthBetween(start, end int) float32 {
+	return float32(font.MeasureString(t.fontFace, string(t.text[start:end])).Ceil())
+}
+
+func (t *TextInput) maxTextWidth() float32 {
+	return t.width - textInputPadding*2 - textInputCursorWidth
+}
+
+// KeyRepeated reports whether key was just pressed or is held long enough to be repeated
+func KeyRepeated(key ebiten.Key) bool {
+	duration := inpututil.KeyPressDuration(key)
+	return duration == 1 || (duration >= keyRepeatDelay && (duration-keyRepeatDelay)%keyRepeatInterval == 0)
+}
@@ -0,0 +1,72 @@
+package ui
+
+import "image/color"
+
+// Theme is a set of accent colors, text and border colors are the same in all themes
+type Theme struct {
+	Name string
+
+	Highlight color.RGBA
+	Selection color.RGBA
+	Ship      color.RGBA
+	Miss      color.RGBA
+	ShipHit   color.RGBA
+	Valid     color.RGBA
+	Invalid   color.RGBA
+}
+
+var (
+	ThemeDefault = Theme{
+		Name:      "Default",
+		Highlight: color.RGBA{R: 236, G: 168, B: 105, A: 255},
+		Selection: color.RGBA{R: 236, G: 168, B: 105, A: 120},
+		Ship:      color.RGBA{R: 83, G: 127, B: 231, A: 255},
+		Miss:      color.RGBA{R: 60, G: 64, B: 72, A: 255},
+		ShipHit:   color.RGBA{R: 245, G: 80, B: 80, A: 255},
+		Valid:     color.RGBA{R: 96, G: 200, B: 120, A: 255},
+		Invalid:   color.RGBA{R: 245, G: 80, B: 80, A: 160},
+	}
+
+	// ThemeColorblind uses colors that are distinguishable with red-green color blindness
+	ThemeColorblind = Theme{
+		Name:      "Colorblind",
+		Highlight: color.RGBA{R: 240, G: 228, B: 66, A: 255},
+		Selection: color.RGBA{R: 240, G: 228, B: 66, A: 120},
+		Ship:      color.RGBA{R: 0, G: 114, B: 178, A: 255},
+		Miss:      color.RGBA{R: 60, G: 64, B: 72, A: 255},
+		ShipHit:   color.RGBA{R: 213, G: 94, B: 0, A: 255},
+		Valid:     color.RGBA{R: 86, G: 180, B: 233, A: 255},
+		Invalid:   color.RGBA{R: 213, G: 94, B: 0, A: 160},
+	}
+)
+
+// DefaultTheme is a theme used if not selected
+var DefaultTheme = ThemeDefault
+
+// Themes lists all themes
+var Themes = []Theme{
+	ThemeDefault,
+	ThemeColorblind,
+}
+
+// ThemeByName returns theme by its name
+func ThemeByName(name string) (Theme, bool) {
+	for _, theme := range Themes {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+
+	return Theme{}, false
+}
+
+// ApplyTheme changes accent colors, they are used starting from the next drawn frame
+func ApplyTheme(theme Theme) {
+	HighlightColor = theme.Highlight
+	SelectionColor = theme.Selection
+	ShipColor = theme.Ship
+	MissColor = theme.Miss
+	ShipHitColor = theme.ShipHit
+	ValidColor = theme.Valid
+	InvalidColor = theme.Invalid
+}